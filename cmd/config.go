@@ -0,0 +1,89 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/pehlicd/crd-wizard/internal/config"
+)
+
+// configFile is the name of the config file looked up in config.Dir when --config is not set.
+const configFile = "config.yaml"
+
+// loadConfigFile sets every flag of cmd that was not given on the command line from the
+// config file. Persistent flags are read from top-level keys named after the flags, and
+// the other flags from keys nested under the command path, e.g. generate.format, so
+// commands sharing a flag name can be configured separately. Flags always override file values.
+func loadConfigFile(cmd *cobra.Command) error {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if cfgFile != "" {
+		v.SetConfigFile(cfgFile)
+	} else {
+		dir, err := config.Dir()
+		if err != nil {
+			// Without a config directory there is no default file to read.
+			return nil
+		}
+		path := filepath.Join(dir, configFile)
+		// The default config file is optional, unlike one given with --config.
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		v.SetConfigFile(path)
+	}
+
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", v.ConfigFileUsed(), err)
+	}
+
+	prefix := configPrefix(cmd)
+	local := cmd.LocalNonPersistentFlags()
+	var errs []error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		key := f.Name
+		if local.Lookup(f.Name) != nil {
+			key = prefix + f.Name
+		}
+		if f.Changed || !v.IsSet(key) {
+			return
+		}
+		if err := setFlagFromConfig(f, v, key); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value for %q in config file %s: %w", key, v.ConfigFileUsed(), err))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// configPrefix returns the config key prefix of the flags local to cmd, which is its
+// command path without the root command, e.g. "generate." for crd-wizard generate.
+func configPrefix(cmd *cobra.Command) string {
+	var names []string
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		names = append([]string{c.Name()}, names...)
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return strings.Join(names, ".") + "."
+}
+
+// setFlagFromConfig sets the flag to the config value of key. Lists may be
+// written as YAML sequences or as comma-separated strings.
+func setFlagFromConfig(f *pflag.Flag, v *viper.Viper, key string) error {
+	if sv, ok := f.Value.(pflag.SliceValue); ok {
+		return sv.Replace(v.GetStringSlice(key))
+	}
+	return f.Value.Set(v.GetString(key))
+}
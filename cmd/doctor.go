@@ -0,0 +1,169 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+*/
+package cmd
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pehlicd/crd-wizard/internal/ai"
+	"github.com/pehlicd/crd-wizard/internal/k8s"
+	"github.com/pehlicd/crd-wizard/internal/logger"
+)
+
+// doctorTimeout bounds each individual check.
+var doctorTimeout time.Duration
+
+// errSkipped marks a check that does not apply to the current configuration.
+var errSkipped = errors.New("skipped")
+
+// doctorCheck is a single diagnosis. run returns a short description of what was found,
+// or an error together with a hint on how to fix it.
+type doctorCheck struct {
+	name string
+	run  func(ctx stdcontext.Context) (detail string, hint string, err error)
+}
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the cluster connection and the AI and search provider configuration",
+	Long: `Run a series of checks against the configured Kubernetes cluster, AI provider and
+search provider, and report each as OK or failed together with hints on how to fix it.
+The AI and search checks use the same flags as the tui and web commands and are only
+run with --enable-ai.`,
+	Example: `
+  # Check the cluster connection only
+  crd-wizard doctor
+
+  # Also check the Gemini provider and Google search
+  crd-wizard doctor --enable-ai --ai-provider gemini --search-provider google`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		log := logger.NewLogger(logFormat, logLevel, io.Discard)
+
+		var aiClient *ai.Client
+		checks := []doctorCheck{
+			{name: "Kubernetes", run: checkKubernetes(log)},
+			{name: "AI config", run: checkAIConfig},
+			{name: "AI provider", run: func(ctx stdcontext.Context) (string, string, error) {
+				return checkAIProvider(ctx, &aiClient, log)
+			}},
+			{name: "Web search", run: func(ctx stdcontext.Context) (string, string, error) {
+				return checkSearch(ctx, aiClient)
+			}},
+		}
+
+		failed := 0
+		for _, check := range checks {
+			ctx, cancel := stdcontext.WithTimeout(cmd.Context(), doctorTimeout)
+			detail, hint, err := check.run(ctx)
+			cancel()
+
+			switch {
+			case errors.Is(err, errSkipped):
+				fmt.Printf("➖ %-12s %s\n", check.name, detail)
+			case err != nil:
+				failed++
+				fmt.Printf("❌ %-12s %v\n", check.name, err)
+				if hint != "" {
+					fmt.Printf("   %-12s → %s\n", "", hint)
+				}
+			default:
+				fmt.Printf("✅ %-12s %s\n", check.name, detail)
+			}
+		}
+
+		if failed > 0 {
+			fmt.Printf("\n%d check(s) failed\n", failed)
+			os.Exit(1)
+		}
+	},
+}
+
+// checkKubernetes connects to the configured cluster and lists a single CRD, which
+// needs the same permissions as browsing CRDs.
+func checkKubernetes(log *logger.Logger) func(ctx stdcontext.Context) (string, string, error) {
+	return func(ctx stdcontext.Context) (string, string, error) {
+		client, err := k8s.NewClient(kubeconfig, context, clientOptions(), log)
+		if err != nil {
+			return "", "check --kubeconfig and --context, or the KUBECONFIG environment variable", err
+		}
+		info, err := client.GetClusterInfo()
+		if err != nil {
+			return "", "make sure the API server of the current context is reachable from this machine", err
+		}
+		if _, err := client.ListCRDs(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+			return "", "grant list access on customresourcedefinitions.apiextensions.k8s.io to your user", fmt.Errorf("cannot list CRDs: %w", err)
+		}
+		return fmt.Sprintf("connected to %s (%s)", info.ClusterName, info.ServerVersion), "", nil
+	}
+}
+
+// checkAIConfig reports missing credentials for the chosen AI and search providers.
+func checkAIConfig(_ stdcontext.Context) (string, string, error) {
+	if !enableAI {
+		return "AI features are disabled, pass --enable-ai to check them", "", errSkipped
+	}
+	if err := aiConfig().Validate(); err != nil {
+		return "", "set the missing flags, environment variables or config file keys", err
+	}
+	return fmt.Sprintf("provider %s, model %s", aiProvider, aiModel), "", nil
+}
+
+// checkAIProvider pings the configured AI provider and stores the client for the search check.
+func checkAIProvider(ctx stdcontext.Context, aiClient **ai.Client, log *logger.Logger) (string, string, error) {
+	if !enableAI {
+		return "AI features are disabled", "", errSkipped
+	}
+	if aiConfig().Validate() != nil {
+		return "the AI configuration is invalid", "", errSkipped
+	}
+
+	*aiClient = ai.NewClient(aiConfig(), nil, log)
+	if err := (*aiClient).Ping(ctx); err != nil {
+		if errors.Is(err, ai.ErrModelNotFound) {
+			return "", fmt.Sprintf("pull the model with 'ollama pull %s' or pass --ollama-auto-pull", aiModel), err
+		}
+		if ai.Provider(aiProvider) == ai.ProviderGemini {
+			return "", "check --gemini-api-key (or GEMINI_API_KEY) and that --ai-model names a Gemini model", err
+		}
+		return "", fmt.Sprintf("start Ollama with 'ollama serve' or point --ollama-host at a running server (currently %s)", ollamaHost), err
+	}
+	if ai.Provider(aiProvider) == ai.ProviderGemini {
+		return "gemini is reachable and the API key is accepted", "", nil
+	}
+	return fmt.Sprintf("ollama is reachable at %s and has model %s", ollamaHost, aiModel), "", nil
+}
+
+// checkSearch runs a sample query against the configured search provider.
+func checkSearch(ctx stdcontext.Context, aiClient *ai.Client) (string, string, error) {
+	if aiClient == nil {
+		return "requires a working AI configuration", "", errSkipped
+	}
+	if !enableSearch {
+		return "web search is disabled", "", errSkipped
+	}
+
+	if _, err := aiClient.Search(ctx, "kubernetes custom resource definition example"); err != nil {
+		if ai.SearchProvider(searchProvider) == ai.SearchProviderGoogle {
+			return "", "check --google-api-key and --google-cx, and that the Custom Search API is enabled for the key", err
+		}
+		return "", "DuckDuckGo may be rate limiting this address, retry later or use --search-provider google", err
+	}
+	return fmt.Sprintf("%s returned results", searchProvider), "", nil
+}
+
+func init() {
+	doctorCmd.Flags().DurationVar(&doctorTimeout, "timeout", 15*time.Second, "Timeout for each individual check")
+
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/pehlicd/crd-wizard/internal/generator"
+	"github.com/pehlicd/crd-wizard/internal/k8s"
+	"github.com/pehlicd/crd-wizard/internal/logger"
+	"github.com/pehlicd/crd-wizard/internal/models"
+	"github.com/pehlicd/crd-wizard/internal/skeleton"
+)
+
+var (
+	exampleFile   string
+	exampleOutput string
+)
+
+// exampleMaxDescription is the number of characters of the first sentence of a description
+// kept as a field comment in example manifests.
+const exampleMaxDescription = 100
+
+// exampleCmd represents the example command
+var exampleCmd = &cobra.Command{
+	Use:   "example [crd-name]",
+	Short: "Generate an example manifest for a CRD",
+	Long: `Generate a skeleton custom resource manifest from the OpenAPI schema of a CRD.
+Required fields are filled with placeholder values and optional fields are commented out.
+The CRD is fetched from the connected cluster, or read from a file with --file. No AI provider is needed.`,
+	Example: `
+  # Print an example Prometheus resource
+  crd-wizard example prometheuses.monitoring.coreos.com
+
+  # Generate an example from a local CRD file into a file
+  crd-wizard example -f path/to/crd.yaml -o example.yaml
+`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := logger.NewLogger(logFormat, logLevel, os.Stderr)
+
+		var crd *apiextensionsv1.CustomResourceDefinition
+		switch {
+		case exampleFile != "":
+			content, err := os.ReadFile(exampleFile)
+			if err != nil {
+				log.Error("failed to read file", "file", exampleFile, "err", err)
+				os.Exit(1)
+			}
+			// ParseCRDs also converts v1beta1 CRDs, only the first CRD of the file is used.
+			crds, _, err := generator.ParseCRDs(content)
+			if err != nil {
+				log.Error("failed to parse CRD", "err", err)
+				os.Exit(1)
+			}
+			crd = &crds[0]
+		case len(args) == 1:
+			client, err := k8s.NewClient(kubeconfig, context, clientOptions(), log)
+			if err != nil {
+				log.Error("unable to create k8s client", "err", err)
+				os.Exit(1)
+			}
+			crd, err = client.GetFullCRD(cmd.Context(), args[0])
+			if err != nil {
+				log.Error("failed to get CRD", "name", args[0], "err", err)
+				os.Exit(1)
+			}
+		default:
+			log.Error("error: you must specify a CRD name or use --file")
+			os.Exit(1)
+		}
+
+		content, err := skeleton.FromCRD(models.ToAPICRD(*crd, 0), skeleton.Options{MaxDescription: exampleMaxDescription})
+		if err != nil {
+			log.Error("failed to generate example", "err", err)
+			os.Exit(1)
+		}
+
+		if exampleOutput == "" || exampleOutput == "-" {
+			if _, err := os.Stdout.WriteString(content); err != nil {
+				log.Error("failed to write to stdout", "err", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		if err := os.WriteFile(exampleOutput, []byte(content), 0644); err != nil { //nolint:gosec // 0644 is intended for manifests
+			log.Error("failed to write file", "file", exampleOutput, "err", err)
+			os.Exit(1)
+		}
+		log.Info("generated example manifest", "file", exampleOutput)
+	},
+}
+
+func init() {
+	exampleCmd.Flags().StringVarP(&exampleFile, "file", "f", "", "Path to a CRD file (YAML or JSON) instead of fetching from the cluster")
+	exampleCmd.Flags().StringVarP(&exampleOutput, "output", "o", "", "Output file (defaults to stdout)")
+
+	rootCmd.AddCommand(exampleCmd)
+}
@@ -4,22 +4,45 @@ Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/spf13/cobra"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
+	"github.com/pehlicd/crd-wizard/internal/ai"
 	"github.com/pehlicd/crd-wizard/internal/generator"
 	"github.com/pehlicd/crd-wizard/internal/k8s"
 	"github.com/pehlicd/crd-wizard/internal/logger"
 	"github.com/pehlicd/crd-wizard/internal/models"
+	"github.com/pehlicd/crd-wizard/internal/skeleton"
 )
 
 var (
-	exportAll    bool
-	exportFormat string
-	exportOutput string
+	exportAll          bool
+	exportFormat       string
+	exportOutput       string
+	exportConcurrency  int
+	htmlTitle          string
+	htmlLogo           string
+	htmlCSS            string
+	maxDescLength      int
+	structuredMetadata bool
+	exportGroups       []string
+	exportSelector     string
+	includeExamples    bool
+	aiEnrichDocs       bool
+	exportResume       bool
 )
 
 // exportCmd represents the export command
@@ -27,8 +50,9 @@ var exportCmd = &cobra.Command{
 	Use:   "export [crd-name]",
 	Short: "Export documentation for CRDs from the cluster",
 	Long: `Export documentation for Custom Resource Definitions (CRDs) present in the connected Kubernetes cluster.
-You can export a single CRD by name or all CRDs using the --all flag.
-Supported formats are HTML and Markdown.`,
+You can export a single CRD by name or all CRDs using the --all flag. --group and --selector
+export only the CRDs of some API groups or with matching labels.
+Supported formats are HTML, Markdown and JSON.`,
 	Example: `
   # Export a single CRD to HTML (default)
   crd-wizard export alertmanagers.monitoring.coreos.com
@@ -36,68 +60,97 @@ Supported formats are HTML and Markdown.`,
   # Export all CRDs to Markdown
   crd-wizard export --all --format md --output ./docs/
 
+  # Export only the CRDs of one operator
+  crd-wizard export --group monitoring.coreos.com --output ./docs/
+  crd-wizard export --selector app.kubernetes.io/part-of=my-operator --output ./docs/
+
   # Export to specific file
   crd-wizard export prometheuses.monitoring.coreos.com -o prometheus.html
+
+  # Continue an interrupted export, skipping the CRDs already written
+  crd-wizard export --all --output ./docs/ --resume
+
+  # Let AI describe the fields the CRD leaves undocumented
+  crd-wizard export --all --enable-ai --ai-enrich-docs --output ./docs/
 `,
+	PreRunE: validateAIFlags,
 	Run: func(cmd *cobra.Command, args []string) {
 		log := logger.NewLogger(logFormat, logLevel, os.Stderr)
 
+		filter := k8s.CRDFilter{Groups: exportGroups, Selector: exportSelector}
+		filtered := len(filter.Groups) > 0 || filter.Selector != ""
+		if filtered && len(args) > 0 {
+			log.Error("error: --group and --selector cannot be combined with a CRD name")
+			os.Exit(1)
+		}
+		// Filters select a subset of all CRDs, so they imply --all.
+		exportAll = exportAll || filtered
+
 		if !exportAll && len(args) == 0 {
 			log.Error("error: you must specify a CRD name or use --all")
 			os.Exit(1)
 		}
+		if exportResume && !exportAll {
+			log.Error("error: --resume only applies to --all")
+			os.Exit(1)
+		}
+		if err := validateExportOutput(exportAll, exportOutput); err != nil {
+			log.Error("error: invalid --output", "output", exportOutput, "err", err)
+			os.Exit(1)
+		}
 
-		client, err := k8s.NewClient(kubeconfig, context, log)
+		client, err := k8s.NewClient(kubeconfig, context, clientOptions(), log)
 		if err != nil {
 			log.Error("unable to create k8s client", "err", err)
 			os.Exit(1)
 		}
 
-		gen := generator.NewGenerator()
+		opts, err := generatorOptions(cmd, log)
+		if err != nil {
+			log.Error("error: invalid generator options", "err", err)
+			os.Exit(1)
+		}
+		gen := generator.NewGenerator(opts)
 
 		if exportAll {
-			// List all CRDs
-			crds, err := client.GetCRDs(cmd.Context()) // This returns models.CRD, not full CRD
+			crds, err := client.ListCRDsFiltered(cmd.Context(), filter)
 			if err != nil {
 				log.Error("failed to list CRDs", "err", err)
 				os.Exit(1)
 			}
+			if len(crds) == 0 {
+				log.Warn("no CRDs match the filters", "groups", filter.Groups, "selector", filter.Selector)
+			}
 
-			// We need full CRDs for generation.
-			// GetCRDs returns a simplified struct. We might need to iterate and fetch full CRDs.
-			// Or modify GetCRDs to return what we need, but that might affect other parts.
-			// Better to just fetch names and then GetFullCRD for each.
-
-			for _, simpleCRD := range crds {
-				fullCRD, err := client.GetFullCRD(cmd.Context(), simpleCRD.Name)
-				if err != nil {
-					log.Error("failed to get full CRD", "name", simpleCRD.Name, "err", err)
-					continue
+			if exportOutput != "" {
+				// validateExportOutput made sure exportOutput is meant as a directory for --all
+				if err := os.MkdirAll(exportOutput, 0755); err != nil { //nolint:gosec // 0755 is intended for documentation
+					log.Error("failed to create output directory", "dir", exportOutput, "err", err)
+					os.Exit(1)
 				}
+			}
 
-				// Convert to APICRD
-				apiCRD := models.ToAPICRD(*fullCRD, 0)
-
-				content, err := gen.Generate(apiCRD, exportFormat)
-				if err != nil {
-					log.Error("failed to generate documentation", "name", simpleCRD.Name, "err", err)
-					continue
-				}
+			index, skipped := exportCRDs(cmd, client, gen, crds, exportFormat, exportOutput, false, log)
 
-				filename := fmt.Sprintf("%s.%s", simpleCRD.Name, getExtension(exportFormat))
-				if exportOutput != "" {
-					// Assume exportOutput is a directory for --all
-					// Ensure directory exists
-					_ = os.MkdirAll(exportOutput, 0755)
-					filename = fmt.Sprintf("%s/%s", exportOutput, filename)
-				}
+			content, err := gen.Index(index, exportFormat)
+			if err != nil {
+				log.Error("failed to generate index", "err", err)
+				os.Exit(1)
+			}
+			indexFile := fmt.Sprintf("index.%s", getExtension(exportFormat))
+			if exportOutput != "" {
+				indexFile = fmt.Sprintf("%s/%s", exportOutput, indexFile)
+			}
+			if err := os.WriteFile(indexFile, content, 0644); err != nil { //nolint:gosec // 0644 is intended for documentation
+				log.Error("failed to write file", "file", indexFile, "err", err)
+				os.Exit(1)
+			}
+			log.Info("generated index", "file", indexFile, "crds", len(index))
 
-				err = os.WriteFile(filename, content, 0644) //nolint:gosec // 0644 is intended for documentation
-				if err != nil {
-					log.Error("failed to write file", "file", filename, "err", err)
-					continue
-				}
-				log.Info("generated documentation", "file", filename)
+			if len(skipped) > 0 {
+				log.Warn("some CRDs were not exported", "exported", len(index), "skipped", len(skipped), "crds", strings.Join(skipped, "; "))
+			} else {
+				log.Info("exported all CRDs", "exported", len(index))
 			}
 
 		} else {
@@ -109,7 +162,7 @@ Supported formats are HTML and Markdown.`,
 			}
 
 			apiCRD := models.ToAPICRD(*fullCRD, 0)
-			content, err := gen.Generate(apiCRD, exportFormat)
+			content, err := generateDoc(cmd, client, gen, apiCRD, exportFormat, log)
 			if err != nil {
 				log.Error("failed to generate documentation", "err", err)
 				os.Exit(1)
@@ -138,17 +191,275 @@ Supported formats are HTML and Markdown.`,
 	},
 }
 
+// exportCRDs renders the documentation of crds in format into dir with a bounded pool of
+// workers, mirroring the web ExportAllHandler. It returns the index entries of the written
+// documents, with instance counts if countInstances is set, and the CRDs that could not be
+// exported with the reason why. With --resume, documents that already exist are kept as they
+// are and only listed in the index.
+func exportCRDs(cmd *cobra.Command, client *k8s.Client, gen *generator.Generator, crds []apiextensionsv1.CustomResourceDefinition, format, dir string, countInstances bool, log *logger.Logger) ([]generator.IndexEntry, []string) {
+	progress := newExportProgress(len(crds))
+	stopProgress := progress.logPeriodically(log, exportProgressInterval)
+	defer stopProgress()
+
+	semaphore := make(chan struct{}, max(exportConcurrency, 1))
+	var wg sync.WaitGroup
+
+	// Every exported document is listed in the index written once all workers are done,
+	// every CRD that could not be exported in the summary logged at the end.
+	var indexMu sync.Mutex
+	var index []generator.IndexEntry
+	var skipped []string
+	skip := func(name string, err error) {
+		indexMu.Lock()
+		defer indexMu.Unlock()
+		skipped = append(skipped, fmt.Sprintf("%s (%v)", name, err))
+	}
+
+	for _, crd := range crds {
+		wg.Add(1)
+		semaphore <- struct{}{} // Acquire token
+
+		go func(crd apiextensionsv1.CustomResourceDefinition) {
+			defer wg.Done()
+			defer func() { <-semaphore }() // Release token
+			name := crd.Name
+
+			// Convert to APICRD
+			apiCRD := models.ToAPICRD(crd, 0)
+
+			file := fmt.Sprintf("%s.%s", name, getExtension(format))
+			filename := file
+			if dir != "" {
+				filename = fmt.Sprintf("%s/%s", dir, filename)
+			}
+
+			if _, err := os.Stat(filename); exportResume && err == nil {
+				log.Info("skipping documentation that already exists", append([]any{"file", filename}, progress.step()...)...)
+			} else {
+				content, err := generateDoc(cmd, client, gen, apiCRD, format, log)
+				if err != nil {
+					log.Error("failed to generate documentation", append([]any{"name", name, "err", err}, progress.step()...)...)
+					skip(name, err)
+					return
+				}
+
+				// Each CRD is written to its own file, so no synchronization is needed here. The file is
+				// renamed into place, so an interrupted export leaves no partial document for --resume.
+				err = os.WriteFile(filename+".tmp", content, 0644) //nolint:gosec // 0644 is intended for documentation
+				if err == nil {
+					err = os.Rename(filename+".tmp", filename)
+				}
+				if err != nil {
+					log.Error("failed to write file", append([]any{"file", filename, "err", err}, progress.step()...)...)
+					skip(name, err)
+					return
+				}
+				log.Info("generated documentation", append([]any{"file", filename}, progress.step()...)...)
+			}
+
+			entry := generator.IndexEntry{Name: name, Kind: apiCRD.Spec.Names.Kind, Group: apiCRD.Spec.Group, File: file}
+			if countInstances {
+				instances := client.CountCRDInstances(cmd.Context(), crd)
+				entry.Instances = &instances
+			}
+			indexMu.Lock()
+			index = append(index, entry)
+			indexMu.Unlock()
+		}(crd)
+	}
+
+	wg.Wait()
+	sort.Strings(skipped)
+	return index, skipped
+}
+
+// validateExportOutput checks that --output is a directory for --all, where one file is
+// written per CRD, and a file or - (stdout) for a single CRD.
+func validateExportOutput(all bool, output string) error {
+	if output == "" {
+		return nil
+	}
+	info, statErr := os.Stat(output)
+	isDir := statErr == nil && info.IsDir()
+
+	if all {
+		switch {
+		case output == "-":
+			return fmt.Errorf("--all writes one file per CRD and cannot write to stdout, pass a directory")
+		case statErr == nil && !isDir:
+			return fmt.Errorf("%s is a file, --all needs a directory to write one file per CRD into", output)
+		case statErr != nil && slices.Contains([]string{".html", ".md", ".json"}, strings.ToLower(filepath.Ext(output))):
+			return fmt.Errorf("%s looks like a file name, --all needs a directory to write one file per CRD into", output)
+		}
+		return nil
+	}
+
+	if isDir || strings.HasSuffix(output, "/") {
+		return fmt.Errorf("%s is a directory, pass a file name for a single CRD or use --all", output)
+	}
+	return nil
+}
+
+// exportProgressInterval is how often export --all logs its progress while no CRD finishes.
+const exportProgressInterval = 10 * time.Second
+
+// exportProgress counts the CRDs handled by export --all and estimates the time remaining.
+type exportProgress struct {
+	total int
+	done  atomic.Int64
+	start time.Time
+}
+
+func newExportProgress(total int) *exportProgress {
+	return &exportProgress{total: total, start: time.Now()}
+}
+
+// step records a handled CRD, exported or failed, and returns its progress as log attributes.
+func (p *exportProgress) step() []any {
+	return p.attrs(int(p.done.Add(1)))
+}
+
+// attrs returns the progress after done CRDs as log attributes, e.g. progress=45/300 remaining=1m20s.
+func (p *exportProgress) attrs(done int) []any {
+	attrs := []any{"progress", fmt.Sprintf("%d/%d", done, p.total)}
+	if done > 0 && done < p.total {
+		perCRD := time.Since(p.start) / time.Duration(done)
+		attrs = append(attrs, "remaining", (perCRD * time.Duration(p.total-done)).Round(time.Second))
+	}
+	return attrs
+}
+
+// logPeriodically logs the progress every interval in which no CRD finished, so slow exports do
+// not look hung. The returned function stops it.
+func (p *exportProgress) logPeriodically(log *logger.Logger, interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		last := -1
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if n := int(p.done.Load()); n == last {
+					log.Info("still exporting", p.attrs(n)...)
+				} else {
+					last = n
+				}
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
+
+// generateDoc renders the documentation of a CRD in format, embedding examples when --include-examples is set.
+// Live instances, sampled as set by --ai-example-limit and --ai-example-namespace, are preferred;
+// the schema skeleton is used when the CRD has none.
+func generateDoc(cmd *cobra.Command, client *k8s.Client, gen *generator.Generator, crd models.APICRD, format string, log *logger.Logger) ([]byte, error) {
+	data, err := gen.Parse(crd)
+	if err != nil {
+		return nil, err
+	}
+
+	if includeExamples {
+		version := ""
+		for _, v := range crd.Spec.Versions {
+			if version == "" || v.Storage {
+				version = v.Name
+			}
+		}
+
+		examples, err := client.FetchCRDExamples(cmd.Context(), crd.Spec.Group, version, crd.Spec.Names.Kind, k8s.ExampleOptions{
+			Limit:     int64(exampleLimit),
+			Namespace: exampleNamespace,
+		})
+		if err != nil {
+			log.Warn("failed to fetch examples, using the schema skeleton", "name", crd.Metadata.Name, "err", err)
+		}
+		if examples != "" {
+			data.Examples = strings.Split(examples, "\n---\n")
+		} else if example, err := skeleton.FromCRD(crd, skeleton.Options{MaxDescription: exampleMaxDescription}); err == nil {
+			data.Examples = []string{example}
+			data.ExamplesFromSchema = true
+		} else {
+			log.Warn("failed to generate the schema skeleton", "name", crd.Metadata.Name, "err", err)
+		}
+	}
+
+	return gen.Render(data, format)
+}
+
+// generatorOptions returns the generator options built from the flags registered by
+// addGeneratorFlags: HTML branding, description length, structured metadata and AI enrichment.
+func generatorOptions(cmd *cobra.Command, log *logger.Logger) (generator.Options, error) {
+	opts := generator.Options{
+		HTMLTitle:          htmlTitle,
+		HTMLLogo:           htmlLogo,
+		HTMLCSS:            htmlCSS,
+		MaxDescLength:      maxDescLength,
+		StructuredMetadata: structuredMetadata,
+	}
+	if !aiEnrichDocs {
+		return opts, nil
+	}
+	if !enableAI {
+		return opts, errors.New("--ai-enrich-docs needs --enable-ai")
+	}
+
+	aiClient := ai.NewClient(aiConfig(), nil, log)
+	if err := aiClient.EnsureModel(cmd.Context(), func(msg string) { log.Info("preparing AI model", "status", msg) }); err != nil {
+		return opts, err
+	}
+	// Descriptions are a nice-to-have, so a failing provider leaves the CRD's own in place.
+	opts.DescribeFields = func(schema apiextensionsv1.JSONSchemaProps) map[string]string {
+		schemaJSON, err := json.Marshal(schema)
+		if err != nil {
+			log.Warn("failed to marshal schema for AI descriptions", "err", err)
+			return nil
+		}
+		descriptions, err := aiClient.DescribeFields(cmd.Context(), string(schemaJSON))
+		if err != nil {
+			log.Warn("failed to describe fields with AI, keeping the descriptions of the CRD", "err", err)
+			return nil
+		}
+		return descriptions
+	}
+	return opts, nil
+}
+
+// addGeneratorFlags registers the flags read by generatorOptions on cmd.
+func addGeneratorFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&htmlTitle, "html-title", "", "Title prefix shown in the header of HTML documentation")
+	cmd.Flags().StringVar(&htmlLogo, "html-logo", "", "URL of a logo shown in the header of HTML documentation")
+	cmd.Flags().StringVar(&htmlCSS, "html-css", "", "Extra CSS appended to the stylesheet of HTML documentation")
+	cmd.Flags().IntVar(&maxDescLength, "max-desc-length", 0, "Truncate descriptions longer than this many characters, with a \"show more\" toggle in HTML and an ellipsis in Markdown (0 keeps them whole)")
+	cmd.Flags().BoolVar(&structuredMetadata, "structured-metadata", false, "Embed a JSON-LD block with the group, kind, versions and scope of the CRD in HTML documentation")
+	cmd.Flags().BoolVar(&aiEnrichDocs, "ai-enrich-docs", false, "Let AI write descriptions for fields the CRD leaves undocumented, marked as AI-generated (requires --enable-ai)")
+}
+
 func getExtension(format string) string {
-	if format == "markdown" || format == "md" {
+	switch format {
+	case "markdown", "md":
 		return "md"
+	case "json":
+		return "json"
 	}
 	return "html"
 }
 
 func init() {
 	exportCmd.Flags().BoolVar(&exportAll, "all", false, "Export all CRDs in the cluster")
-	exportCmd.Flags().StringVar(&exportFormat, "format", "html", "Output format (html or markdown)")
-	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output path (file or directory)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "html", "Output format (html, markdown or json)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file for a single CRD (- for stdout), or output directory for --all (created if missing)")
+	exportCmd.Flags().StringSliceVar(&exportGroups, "group", nil, "Only export the CRDs of these API groups (implies --all)")
+	exportCmd.Flags().StringVarP(&exportSelector, "selector", "l", "", "Only export the CRDs matching this label selector (implies --all)")
+	exportCmd.Flags().IntVar(&exportConcurrency, "concurrency", 5, "Number of CRDs to fetch and render concurrently with --all")
+	exportCmd.Flags().BoolVar(&exportResume, "resume", false, "With --all, skip the CRDs whose documentation already exists in the output directory, to continue an interrupted export")
+	exportCmd.Flags().BoolVar(&includeExamples, "include-examples", false, fmt.Sprintf("Embed up to --ai-example-limit (default %d) live instances, or a schema skeleton if there are none, as examples in the documentation", k8s.DefaultExampleLimit))
+	addGeneratorFlags(exportCmd)
 
 	rootCmd.AddCommand(exportCmd)
 }
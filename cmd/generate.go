@@ -6,12 +6,11 @@ package cmd
 import (
 	"fmt"
 	"io"
-	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
-	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	"k8s.io/apimachinery/pkg/util/yaml"
 
 	"github.com/pehlicd/crd-wizard/internal/generator"
 	"github.com/pehlicd/crd-wizard/internal/giturl"
@@ -28,11 +27,15 @@ var (
 var generateCmd = &cobra.Command{
 	Use:   "generate",
 	Short: "Generate documentation from a CRD file",
-	Long: `Generate documentation from a CRD file in either HTML or Markdown format.
+	Long: `Generate documentation from a CRD file in HTML, Markdown or JSON format.
 Example:
   crd-wizard generate -f path/to/crd.yaml -o html > doc.html
-  crd-wizard generate -f path/to/crd.yaml -o markdown > doc.md`,
-	Run: func(_ *cobra.Command, _ []string) {
+  crd-wizard generate -f path/to/crd.yaml -o markdown > doc.md
+  crd-wizard generate -f path/to/crd.yaml --format json -o doc.json
+  kubectl get crd certificates.cert-manager.io -o yaml | crd-wizard generate -f - -o cert.html
+  crd-wizard generate -f path/to/crd.yaml --enable-ai --ai-enrich-docs -o doc.html`,
+	PreRunE: validateAIFlags,
+	Run: func(cmd *cobra.Command, _ []string) {
 		log := logger.NewLogger(logFormat, logLevel, os.Stderr)
 
 		var crdContent []byte
@@ -41,23 +44,18 @@ Example:
 		if generateURL != "" {
 			rawURL := giturl.ConvertGitURLToRaw(generateURL)
 
-			resp, err := http.Get(rawURL) //nolint:gosec // user supplied url is intended used for CLI purposes
+			// The URL comes from the user running the CLI, so private addresses such as an
+			// internal Git server are fine here.
+			crdContent, err = giturl.Fetch(cmd.Context(), rawURL, giturl.FetchOptions{AllowPrivate: true})
 			if err != nil {
 				log.Error("failed to fetch CRD from URL", "url", rawURL, "err", err)
 				os.Exit(1)
 			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode != http.StatusOK {
-				log.Error("failed to fetch CRD from URL", "url", rawURL, "status", resp.Status)
-				os.Exit(1)
-			}
-
-			// Read limited amount to prevent abuse
-			const maxFileSize = 10 * 1024 * 1024 // 10MB
-			crdContent, err = io.ReadAll(io.LimitReader(resp.Body, maxFileSize))
+		} else if generateFile == "-" {
+			// Read from stdin, e.g. piped from kubectl or helm template
+			crdContent, err = io.ReadAll(os.Stdin)
 			if err != nil {
-				log.Error("failed to read CRD content", "err", err)
+				log.Error("failed to read stdin", "err", err)
 				os.Exit(1)
 			}
 		} else if generateFile != "" {
@@ -72,17 +70,41 @@ Example:
 			os.Exit(1)
 		}
 
-		// Parse YAML/JSON to CRD
-		var crd apiextensionsv1.CustomResourceDefinition
-		if err := yaml.Unmarshal(crdContent, &crd); err != nil {
+		// Parse YAML/JSON to CRDs, the input may hold several documents
+		crds, skipped, err := generator.ParseCRDs(crdContent)
+		for _, reason := range skipped {
+			log.Warn("skipping document", "reason", reason)
+		}
+		if err != nil {
 			log.Error("failed to parse CRD", "err", err)
 			os.Exit(1)
 		}
 
-		gen := generator.NewGenerator()
-		apiCRD := models.ToAPICRD(crd, 0)
+		opts, err := generatorOptions(cmd, log)
+		if err != nil {
+			log.Error("error: invalid generator options", "err", err)
+			os.Exit(1)
+		}
+		gen := generator.NewGenerator(opts)
+		apiCRDs := make([]models.APICRD, 0, len(crds))
+		for _, crd := range crds {
+			apiCRDs = append(apiCRDs, models.ToAPICRD(crd, 0))
+		}
+
+		// Several CRDs get a file each plus an index, unless a single file or stdout is requested.
+		if len(apiCRDs) > 1 && isDirTarget(exportOutput) {
+			dir := exportOutput
+			if dir == "" {
+				dir = "."
+			}
+			if err := writeDocsToDir(gen, apiCRDs, dir, log); err != nil {
+				log.Error("failed to write documentation", "dir", dir, "err", err)
+				os.Exit(1)
+			}
+			return
+		}
 
-		content, err := gen.Generate(apiCRD, exportFormat)
+		content, err := gen.GenerateAll(apiCRDs, exportFormat)
 		if err != nil {
 			log.Error("failed to generate documentation", "err", err)
 			os.Exit(1)
@@ -110,11 +132,55 @@ Example:
 	},
 }
 
+// isDirTarget reports whether the output path should be treated as a directory.
+func isDirTarget(path string) bool {
+	if path == "" || strings.HasSuffix(path, "/") {
+		return true
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// writeDocsToDir writes one document per CRD into dir, followed by an index linking them.
+func writeDocsToDir(gen *generator.Generator, crds []models.APICRD, dir string, log *logger.Logger) error {
+	if err := os.MkdirAll(dir, 0755); err != nil { //nolint:gosec // 0755 is intended for documentation
+		return err
+	}
+
+	index := make([]generator.IndexEntry, 0, len(crds))
+	for _, crd := range crds {
+		content, err := gen.Generate(crd, exportFormat)
+		if err != nil {
+			log.Error("failed to generate documentation", "name", crd.Metadata.Name, "err", err)
+			continue
+		}
+
+		file := fmt.Sprintf("%s.%s", crd.Metadata.Name, getExtension(exportFormat))
+		if err := os.WriteFile(filepath.Join(dir, file), content, 0644); err != nil { //nolint:gosec // 0644 is intended for documentation
+			return err
+		}
+		log.Info("generated documentation", "file", filepath.Join(dir, file))
+		index = append(index, generator.IndexEntry{Name: crd.Metadata.Name, Kind: crd.Spec.Names.Kind, Group: crd.Spec.Group, File: file})
+	}
+
+	content, err := gen.Index(index, exportFormat)
+	if err != nil {
+		return err
+	}
+	indexFile := filepath.Join(dir, fmt.Sprintf("index.%s", getExtension(exportFormat)))
+	if err := os.WriteFile(indexFile, content, 0644); err != nil { //nolint:gosec // 0644 is intended for documentation
+		return err
+	}
+	log.Info("generated index", "file", indexFile, "crds", len(index))
+	return nil
+}
+
 func init() {
-	generateCmd.Flags().StringVarP(&generateFile, "file", "f", "", "Path to the CRD file (YAML or JSON)")
+	generateCmd.Flags().StringVarP(&generateFile, "file", "f", "", "Path to the CRD file (YAML or JSON), use - to read from stdin")
 	generateCmd.Flags().StringVarP(&generateURL, "url", "u", "", "URL to the CRD file (Git provider)")
-	generateCmd.Flags().StringVar(&exportFormat, "format", "html", "Output format (html or markdown)")
+	generateCmd.Flags().StringVar(&exportFormat, "format", "html", "Output format (html, markdown or json)")
 	generateCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output path (file or directory, use - for stdout)")
+	addGeneratorFlags(generateCmd)
 
 	rootCmd.AddCommand(generateCmd)
 }
@@ -0,0 +1,126 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pehlicd/crd-wizard/internal/k8s"
+	"github.com/pehlicd/crd-wizard/internal/logger"
+	"github.com/pehlicd/crd-wizard/internal/models"
+)
+
+var (
+	graphNamespace string
+	graphFormat    string
+)
+
+// graphFormats are the output formats supported by the graph command.
+var graphFormats = []string{"tree", "json", "dot", "mermaid", "html"}
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:   "graph [crd-name] [resource-name]",
+	Short: "Print the resource graph of a custom resource",
+	Long: `Print the owner relationships of a custom resource, the same graph shown by the TUI and the web UI.
+Supported formats are tree (default), json, dot (Graphviz), mermaid and html, a self-contained
+page that draws the graph as an interactive diagram.`,
+	Example: `
+  # Print the graph of a Certificate as a tree
+  crd-wizard graph certificates.cert-manager.io my-cert -n default
+
+  # Render the graph with Graphviz
+  crd-wizard graph certificates.cert-manager.io my-cert -n default --format dot | dot -Tsvg > graph.svg
+
+  # Save the graph as an interactive HTML page to share
+  crd-wizard graph certificates.cert-manager.io my-cert -n default --format html > graph.html
+`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := logger.NewLogger(logFormat, logLevel, os.Stderr)
+		crdName, name := args[0], args[1]
+
+		if !slices.Contains(graphFormats, graphFormat) {
+			log.Error("unsupported format, use tree, json, dot, mermaid or html", "format", graphFormat)
+			os.Exit(1)
+		}
+
+		client, err := k8s.NewClient(kubeconfig, context, clientOptions(), log)
+		if err != nil {
+			log.Error("unable to create k8s client", "err", err)
+			os.Exit(1)
+		}
+
+		resource, err := client.GetSingleCR(cmd.Context(), crdName, graphNamespace, name)
+		if err != nil {
+			log.Error("failed to get resource", "crd", crdName, "namespace", graphNamespace, "name", name, "err", err)
+			os.Exit(1)
+		}
+
+		graph, err := client.GetResourceGraphWithOptions(cmd.Context(), string(resource.GetUID()), k8s.GraphOptions{
+			Kinds: []string{resource.GetKind()},
+		})
+		if err != nil {
+			log.Error("failed to build resource graph", "err", err)
+			os.Exit(1)
+		}
+		if graph.Truncated {
+			log.Warn("the scan hit its object limit, the graph may be partial", "limit", graphMaxObjects)
+		}
+
+		title := fmt.Sprintf("%s %s", resource.GetKind(), name)
+		if graphNamespace != "" {
+			title = fmt.Sprintf("%s %s/%s", resource.GetKind(), graphNamespace, name)
+		}
+		out, err := renderGraph(graph, graphFormat, string(resource.GetUID()), title)
+		if err != nil {
+			log.Error("failed to render resource graph", "err", err)
+			os.Exit(1)
+		}
+		if _, err := os.Stdout.WriteString(out); err != nil {
+			log.Error("failed to write to stdout", "err", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// renderGraph renders the graph in the given format, marking the node with the given ID in trees
+// and HTML pages. title names the graph in HTML pages.
+func renderGraph(graph *models.ResourceGraph, format, markedID, title string) (string, error) {
+	switch format {
+	case "tree":
+		return graph.Tree(func(node models.Node) string {
+			label := fmt.Sprintf("[%s: %s]", node.Type, node.QualifiedName())
+			if node.ID == markedID {
+				label += " [*]"
+			}
+			return label
+		}), nil
+	case "json":
+		out, err := json.MarshalIndent(graph, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(out) + "\n", nil
+	case "dot":
+		return graph.DOT(), nil
+	case "mermaid":
+		return graph.Mermaid(), nil
+	case "html":
+		return graph.HTML("Resource graph of "+title, markedID)
+	}
+	return "", fmt.Errorf("unsupported format %q, use tree, json, dot, mermaid or html", format)
+}
+
+func init() {
+	graphCmd.Flags().StringVarP(&graphNamespace, "namespace", "n", "", "Namespace of the resource (empty for cluster-scoped resources)")
+	graphCmd.Flags().StringVar(&graphFormat, "format", "tree", "Output format: tree, json, dot, mermaid or html")
+
+	rootCmd.AddCommand(graphCmd)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pehlicd/crd-wizard/internal/generator"
+	"github.com/pehlicd/crd-wizard/internal/k8s"
+	"github.com/pehlicd/crd-wizard/internal/logger"
+)
+
+var reportOutput string
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Export an HTML report of the CRD landscape of the cluster",
+	Long: `Export a browsable HTML report of the connected cluster into a directory: a landing page with
+the cluster version, its CRDs grouped by API group with their instance counts, and the documentation
+of every CRD linked from it. --group and --selector limit the report to some CRDs.`,
+	Example: `
+  # Write the report of the current cluster to ./cluster-report/
+  crd-wizard report
+
+  # Report only the CRDs of one operator, with examples
+  crd-wizard report --group monitoring.coreos.com --include-examples --output ./monitoring-report/
+`,
+	Args:    cobra.NoArgs,
+	PreRunE: validateAIFlags,
+	Run: func(cmd *cobra.Command, args []string) {
+		log := logger.NewLogger(logFormat, logLevel, os.Stderr)
+
+		if err := validateExportOutput(true, reportOutput); err != nil {
+			log.Error("error: invalid --output", "output", reportOutput, "err", err)
+			os.Exit(1)
+		}
+
+		client, err := k8s.NewClient(kubeconfig, context, clientOptions(), log)
+		if err != nil {
+			log.Error("unable to create k8s client", "err", err)
+			os.Exit(1)
+		}
+
+		opts, err := generatorOptions(cmd, log)
+		if err != nil {
+			log.Error("error: invalid generator options", "err", err)
+			os.Exit(1)
+		}
+		gen := generator.NewGenerator(opts)
+
+		cluster, err := client.GetClusterInfo()
+		if err != nil {
+			log.Error("failed to get cluster info", "err", err)
+			os.Exit(1)
+		}
+
+		filter := k8s.CRDFilter{Groups: exportGroups, Selector: exportSelector}
+		crds, err := client.ListCRDsFiltered(cmd.Context(), filter)
+		if err != nil {
+			log.Error("failed to list CRDs", "err", err)
+			os.Exit(1)
+		}
+		if len(crds) == 0 {
+			log.Warn("no CRDs match the filters", "groups", filter.Groups, "selector", filter.Selector)
+		}
+
+		if err := os.MkdirAll(reportOutput, 0755); err != nil { //nolint:gosec // 0755 is intended for documentation
+			log.Error("failed to create output directory", "dir", reportOutput, "err", err)
+			os.Exit(1)
+		}
+
+		index, skipped := exportCRDs(cmd, client, gen, crds, "html", reportOutput, true, log)
+
+		content, err := gen.Report(cluster, index, skipped)
+		if err != nil {
+			log.Error("failed to generate report", "err", err)
+			os.Exit(1)
+		}
+		reportFile := filepath.Join(reportOutput, "index.html")
+		if err := os.WriteFile(reportFile, content, 0644); err != nil { //nolint:gosec // 0644 is intended for documentation
+			log.Error("failed to write file", "file", reportFile, "err", err)
+			os.Exit(1)
+		}
+
+		if len(skipped) > 0 {
+			log.Warn("some CRDs are missing from the report", "documented", len(index), "skipped", len(skipped), "crds", strings.Join(skipped, "; "))
+		}
+		log.Info("generated cluster report", "file", reportFile, "crds", len(index))
+	},
+}
+
+func init() {
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "cluster-report", "Output directory of the report (created if missing)")
+	reportCmd.Flags().StringSliceVar(&exportGroups, "group", nil, "Only report the CRDs of these API groups")
+	reportCmd.Flags().StringVarP(&exportSelector, "selector", "l", "", "Only report the CRDs matching this label selector")
+	reportCmd.Flags().IntVar(&exportConcurrency, "concurrency", 5, "Number of CRDs to fetch and render concurrently")
+	reportCmd.Flags().BoolVar(&includeExamples, "include-examples", false, fmt.Sprintf("Embed up to --ai-example-limit (default %d) live instances, or a schema skeleton if there are none, as examples in the documentation", k8s.DefaultExampleLimit))
+	addGeneratorFlags(reportCmd)
+
+	rootCmd.AddCommand(reportCmd)
+}
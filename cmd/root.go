@@ -17,9 +17,14 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/pehlicd/crd-wizard/internal/ai"
+	"github.com/pehlicd/crd-wizard/internal/k8s"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -31,21 +36,58 @@ explore Custom Resource Definitions (CRDs) in your Kubernetes cluster:
 
 - A beautiful and interactive Terminal User Interface (TUI)
 - A simple web server providing a JSON API for CRDs`,
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		if err := loadConfigFile(cmd); err != nil {
+			return err
+		}
+		applyEnvFallbacks()
+		// Quiet mode keeps errors only, whatever the log level says.
+		if quiet {
+			logLevel = "error"
+		}
+		return nil
+	},
 }
 
 var (
+	cfgFile,
 	kubeconfig, context,
 	logFormat, logLevel string
+	quiet bool
+
+	// Kubernetes Client Flags
+	instanceCountTimeout time.Duration
+	eventsTimeout        time.Duration
+	graphCacheTTL        time.Duration
+	graphRestrictKinds   bool
+	graphKinds           []string
+	graphMaxObjects      int
+	graphMaxPerType      int
+	graphCustomOnly      bool
+	graphGroups          []string
+	readOnly             bool
+	insecureSkipTLS      bool
+	certificateAuthority string
+	qps                  float64
+	burst                int
 
 	// AI Configuration Flags
-	enableAI        bool
-	aiProvider      string
-	aiModel         string
-	ollamaHost      string
-	ollamaNumCtx    int
-	ollamaKeepAlive string
-	requestTimeout  int // in minutes
-	enableCache     bool
+	enableAI         bool
+	aiProvider       string
+	aiModel          string
+	aiLanguage       string
+	ollamaHost       string
+	ollamaNumCtx     int
+	ollamaKeepAlive  string
+	ollamaNumPredict int
+	ollamaAutoPull   bool
+	maxOutputTokens  int
+	requestTimeout   int // in minutes
+	enableCache      bool
+
+	// Live Example Flags
+	exampleLimit     int
+	exampleNamespace string
 
 	// Search Configuration Flags
 	enableSearch   bool
@@ -66,27 +108,142 @@ func Execute() {
 	}
 }
 
+// envFallbacks maps credential flags to the environment variables read when neither the flag nor the config file sets them,
+// which keeps secrets out of shell history and process listings.
+var envFallbacks = []struct {
+	value *string
+	env   string
+}{
+	{&geminiAPIKey, "GEMINI_API_KEY"},
+	{&googleAPIKey, "GOOGLE_API_KEY"},
+	{&googleCX, "GOOGLE_CX"},
+	{&webhookToken, "CRD_WIZARD_WEBHOOK_TOKEN"},
+}
+
+// applyEnvFallbacks fills empty credential flags from their environment variables.
+func applyEnvFallbacks() {
+	for _, f := range envFallbacks {
+		if *f.value == "" {
+			*f.value = os.Getenv(f.env)
+		}
+	}
+}
+
+// clientOptions builds the Kubernetes client options from the persistent flags.
+func clientOptions() k8s.Options {
+	return k8s.Options{
+		InstanceCountTimeout:     instanceCountTimeout,
+		EventsTimeout:            eventsTimeout,
+		GraphCacheTTL:            graphCacheTTL,
+		GraphKinds:               restrictedGraphKinds(),
+		GraphMaxObjects:          graphMaxObjects,
+		GraphMaxObjectsPerType:   graphMaxPerType,
+		GraphCustomResourcesOnly: graphCustomOnly,
+		GraphGroups:              graphGroups,
+		ReadOnly:                 readOnly,
+		InsecureSkipTLSVerify:    insecureSkipTLS,
+		CertificateAuthority:     certificateAuthority,
+		QPS:                      float32(qps),
+		Burst:                    burst,
+	}
+}
+
+// aiConfig builds the AI configuration from the persistent flags.
+func aiConfig() ai.Config {
+	return ai.Config{
+		Provider:         ai.Provider(aiProvider),
+		Model:            aiModel,
+		Language:         aiLanguage,
+		OllamaHost:       ollamaHost,
+		RequestTimeout:   time.Duration(requestTimeout) * time.Minute,
+		OllamaNumCtx:     ollamaNumCtx,
+		OllamaKeepAlive:  ollamaKeepAlive,
+		OllamaNumPredict: ollamaNumPredict,
+		MaxOutputTokens:  maxOutputTokens,
+		OllamaAutoPull:   ollamaAutoPull,
+		EnableCache:      enableCache,
+
+		// Live Example Configuration
+		ExampleLimit:     exampleLimit,
+		ExampleNamespace: exampleNamespace,
+
+		// Search Configuration
+		EnableSearch:   enableSearch,
+		SearchProvider: ai.SearchProvider(searchProvider),
+		GoogleAPIKey:   googleAPIKey,
+		GoogleCX:       googleCX,
+		GeminiAPIKey:   geminiAPIKey,
+	}
+}
+
+// validateAIFlags fails commands early when AI is enabled without the settings its providers need.
+func validateAIFlags(cmd *cobra.Command, _ []string) error {
+	if !enableAI {
+		return nil
+	}
+	if err := aiConfig().Validate(); err != nil {
+		// The flags parsed fine, so the usage text would only bury the actual problem.
+		cmd.SilenceUsage = true
+		return fmt.Errorf("invalid AI configuration:\n%w", err)
+	}
+	return nil
+}
+
+// restrictedGraphKinds returns the kinds resource graphs are restricted to, nil when they are not.
+func restrictedGraphKinds() []string {
+	if !graphRestrictKinds {
+		return nil
+	}
+	return graphKinds
+}
+
 func init() {
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "path to a YAML config file whose keys are flag names, nested under the command name for command flags (defaults to config.yaml in the user config directory)")
 	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "path to the kubeconfig file (optional)")
 	rootCmd.PersistentFlags().StringVar(&context, "context", "", "context name (optional)")
 	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "log format")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Only print errors, for use in scripts and pipelines (overrides --log-level)")
+
+	// Kubernetes Client Flags
+	rootCmd.PersistentFlags().DurationVar(&instanceCountTimeout, "instance-count-timeout", 5*time.Second, "Timeout for listing instances when counting them per CRD (too low a value undercounts CRDs with many instances)")
+	rootCmd.PersistentFlags().DurationVar(&eventsTimeout, "events-timeout", 10*time.Second, "Timeout for listing events related to a resource")
+	rootCmd.PersistentFlags().DurationVar(&graphCacheTTL, "graph-cache-ttl", 30*time.Second, "How long the cluster scan behind resource graphs is reused (0 disables the cache)")
+	rootCmd.PersistentFlags().BoolVar(&graphRestrictKinds, "graph-restrict-kinds", false, "Only scan the kinds given by --graph-kinds, the kind of the resource and the kinds of their owners when building resource graphs")
+	rootCmd.PersistentFlags().StringSliceVar(&graphKinds, "graph-kinds", k8s.DefaultGraphKinds, "Kinds scanned for resource graphs when --graph-restrict-kinds is set")
+	rootCmd.PersistentFlags().IntVar(&graphMaxObjects, "graph-max-objects", 200000, "Maximum number of objects held in memory while building resource graphs, larger clusters get partial graphs (0 disables the limit)")
+	rootCmd.PersistentFlags().IntVar(&graphMaxPerType, "graph-max-objects-per-type", 50000, "Maximum number of objects listed per resource type while building resource graphs (0 disables the limit)")
+	rootCmd.PersistentFlags().BoolVar(&graphCustomOnly, "graph-custom-resources-only", false, "Only scan resource types defined by CRDs when building resource graphs, leaving out built-in resources")
+	rootCmd.PersistentFlags().StringSliceVar(&graphGroups, "graph-groups", nil, "Only scan these API groups when building resource graphs, use \"core\" for the core group (defaults to all groups)")
+	rootCmd.PersistentFlags().BoolVar(&readOnly, "read-only", false, "Refuse every request that would create, update or delete cluster resources")
+	rootCmd.PersistentFlags().BoolVar(&insecureSkipTLS, "insecure-skip-tls-verify", false, "Do not verify the API server certificate, which makes the connection insecure")
+	rootCmd.PersistentFlags().StringVar(&certificateAuthority, "certificate-authority", "", "Path to a CA certificate file used to verify the API server instead of the one in the kubeconfig")
+	rootCmd.PersistentFlags().Float64Var(&qps, "qps", k8s.DefaultQPS, "Maximum sustained number of requests per second sent to the API server")
+	rootCmd.PersistentFlags().IntVar(&burst, "burst", k8s.DefaultBurst, "Maximum number of requests sent to the API server in a burst above --qps")
 
 	// AI Flags
 	rootCmd.PersistentFlags().BoolVar(&enableAI, "enable-ai", false, "Enable AI features")
 	rootCmd.PersistentFlags().StringVar(&aiProvider, "ai-provider", "ollama", "AI provider to use (ollama, gemini, etc.)")
 	rootCmd.PersistentFlags().StringVar(&aiModel, "ai-model", "pehlicd/crd-wizard", "Model to use for AI analysis and generation")
+	rootCmd.PersistentFlags().StringVar(&aiLanguage, "ai-language", "", "Language of AI explanations and field descriptions, e.g. German or ja (defaults to English, YAML is kept as is)")
 	rootCmd.PersistentFlags().StringVar(&ollamaHost, "ollama-host", "http://localhost:11434", "Ollama API host (only for ollama provider)")
 	rootCmd.PersistentFlags().IntVar(&ollamaNumCtx, "ollama-num-ctx", 0, "Ollama context window size")
 	rootCmd.PersistentFlags().StringVar(&ollamaKeepAlive, "ollama-keep-alive", "", "Ollama keep-alive duration")
+	rootCmd.PersistentFlags().IntVar(&ollamaNumPredict, "ollama-num-predict", 4096, "Maximum number of tokens Ollama may generate per request (0 uses the model default)")
+	rootCmd.PersistentFlags().BoolVar(&ollamaAutoPull, "ollama-auto-pull", false, "Pull the AI model at startup when Ollama does not have it")
+	rootCmd.PersistentFlags().IntVar(&maxOutputTokens, "ai-max-output-tokens", 8192, "Maximum number of output tokens for hosted AI providers such as Gemini (0 uses the model default)")
 	rootCmd.PersistentFlags().IntVar(&requestTimeout, "request-timeout", 2, "Timeout in minutes for AI requests")
 	rootCmd.PersistentFlags().BoolVar(&enableCache, "enable-cache", true, "Enable caching of AI responses")
 
+	// Live Example Flags
+	rootCmd.PersistentFlags().IntVar(&exampleLimit, "ai-example-limit", k8s.DefaultExampleLimit, "Number of live CRD instances sampled as examples for AI generation and --include-examples")
+	rootCmd.PersistentFlags().StringVar(&exampleNamespace, "ai-example-namespace", "", "Only sample live examples for AI generation and --include-examples from this namespace (defaults to all namespaces)")
+
 	// Search Flags
 	rootCmd.PersistentFlags().BoolVar(&enableSearch, "enable-search", true, "Enable web search for CRD documentation (requires enable-ai)")
 	rootCmd.PersistentFlags().StringVar(&searchProvider, "search-provider", "ddg", "Search provider to use: 'ddg' (DuckDuckGo, free) or 'google' (Requires API Key)")
-	rootCmd.PersistentFlags().StringVar(&googleAPIKey, "google-api-key", "", "Google Custom Search API Key (required if search-provider is google)")
-	rootCmd.PersistentFlags().StringVar(&googleCX, "google-cx", "", "Google Custom Search Engine ID (required if search-provider is google)")
+	rootCmd.PersistentFlags().StringVar(&googleAPIKey, "google-api-key", "", "Google Custom Search API Key (required if search-provider is google, defaults to $GOOGLE_API_KEY)")
+	rootCmd.PersistentFlags().StringVar(&googleCX, "google-cx", "", "Google Custom Search Engine ID (required if search-provider is google, defaults to $GOOGLE_CX)")
 
-	rootCmd.PersistentFlags().StringVar(&geminiAPIKey, "gemini-api-key", "", "Gemini API Key (required if ai-provider is gemini)")
+	rootCmd.PersistentFlags().StringVar(&geminiAPIKey, "gemini-api-key", "", "Gemini API Key (required if ai-provider is gemini, defaults to $GEMINI_API_KEY)")
 }
@@ -17,10 +17,8 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
-	"fmt"
 	"io"
 	"os"
-	"time"
 
 	"github.com/pehlicd/crd-wizard/internal/ai"
 	"github.com/pehlicd/crd-wizard/internal/k8s"
@@ -55,40 +53,34 @@ the TUI pre-focused on a specific CRD or Kind.`,
 
   # Launch and focus on a Kind and specific CRD
   crd-wizard tui --crd alertmanagers.monitoring.coreos.com --kind Prometheus`,
-	Run: func(_ *cobra.Command, _ []string) {
+	PreRunE: validateAIFlags,
+	Run: func(cmd *cobra.Command, _ []string) {
+		// Startup messages go to stderr, everything logged once the TUI owns the terminal is
+		// discarded. The discarding logger is created last so that klog ends up discarded too.
+		startupLog := logger.NewLogger(logFormat, logLevel, os.Stderr)
 		log := logger.NewLogger(logFormat, logLevel, io.Discard)
 
 		// Initialize the ClusterManager to load all contexts.
-		clusterManager, err := k8s.NewClusterManager(kubeconfig, log)
+		clusterManager, err := k8s.NewClusterManager(kubeconfig, clientOptions(), log)
 		if err != nil {
-			fmt.Printf("❌ Could not create cluster manager: %v\n", err)
+			startupLog.Error("could not create cluster manager", "err", err)
 			os.Exit(1)
 		}
-		fmt.Printf("✅ Loaded %d cluster(s) from kubeconfig\n", clusterManager.ClusterCount())
+		startupLog.Info("found contexts in kubeconfig", "contexts", clusterManager.ContextCount())
 
 		var aiClient *ai.Client
 		if enableAI {
-			aiConfig := ai.Config{
-				Provider:        ai.Provider(aiProvider),
-				Model:           aiModel,
-				OllamaHost:      ollamaHost,
-				RequestTimeout:  time.Duration(requestTimeout) * time.Minute,
-				OllamaNumCtx:    ollamaNumCtx,
-				OllamaKeepAlive: ollamaKeepAlive,
-				EnableCache:     enableCache,
-				EnableSearch:    enableSearch,
-				SearchProvider:  ai.SearchProvider(searchProvider),
-				GoogleAPIKey:    googleAPIKey,
-				GoogleCX:        googleCX,
-				GeminiAPIKey:    geminiAPIKey,
-			}
 			// AI client needs a single K8s client for context fetching, use current
-			aiClient = ai.NewClient(aiConfig, clusterManager.GetCurrentClient(), log)
+			aiClient = ai.NewClient(aiConfig(), clusterManager.GetCurrentClient(), log)
+			if err := aiClient.EnsureModel(cmd.Context(), func(msg string) { startupLog.Info("preparing AI model", "status", msg) }); err != nil {
+				startupLog.Error("AI model is not available", "err", err)
+				os.Exit(1)
+			}
 		}
 
 		// Start the TUI.
-		if err := tui.Start(clusterManager, aiClient, crd, kind); err != nil {
-			fmt.Printf("❌ TUI Error: %v\n", err)
+		if err := tui.Start(clusterManager, aiClient, crd, kind, startupLog); err != nil {
+			startupLog.Error("TUI error", "err", err)
 			os.Exit(1)
 		}
 	},
@@ -17,31 +17,62 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"runtime"
 
 	"github.com/spf13/cobra"
+
+	"github.com/pehlicd/crd-wizard/internal/models"
 )
 
 var (
 	versionString string
 	buildDate     string
 	buildCommit   string
+	versionOutput string
 )
 
 // versionCmd represents the version command
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version information of crd-wizard",
-	Long:  `This command will print the version information of crd-wizard and exit.`,
-	Run: func(_ *cobra.Command, _ []string) {
-		fmt.Printf("CR(D) Wizard version: %s\n", versionString)
-		fmt.Printf("Build date: %s\n", buildDate)
-		fmt.Printf("Build commit: %s\n", buildCommit)
-		os.Exit(0)
+	Long: `This command will print the version information of crd-wizard and exit.
+Use --output json for output that scripts and CI can parse.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		info := buildInfo()
+		switch versionOutput {
+		case "json":
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(info)
+		case "text", "":
+			fmt.Printf("CR(D) Wizard version: %s\n", info.Version)
+			fmt.Printf("Build date: %s\n", info.BuildDate)
+			fmt.Printf("Build commit: %s\n", info.Commit)
+			fmt.Printf("Go version: %s\n", info.GoVersion)
+			fmt.Printf("Platform: %s\n", info.Platform)
+			return nil
+		default:
+			cmd.SilenceUsage = true
+			return fmt.Errorf("unsupported output format %q, use text or json", versionOutput)
+		}
 	},
 }
 
+// buildInfo returns the version information set at build time.
+func buildInfo() models.BuildInfo {
+	return models.BuildInfo{
+		Version:   versionString,
+		BuildDate: buildDate,
+		Commit:    buildCommit,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+}
+
 func init() {
+	versionCmd.Flags().StringVarP(&versionOutput, "output", "o", "text", "Output format (text or json)")
 	rootCmd.AddCommand(versionCmd)
 }
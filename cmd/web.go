@@ -17,10 +17,18 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
+	"fmt"
+	"net"
 	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/pehlicd/crd-wizard/internal/ai"
+	"github.com/pehlicd/crd-wizard/internal/giturl"
 	"github.com/pehlicd/crd-wizard/internal/k8s"
 	"github.com/pehlicd/crd-wizard/internal/logger"
 	"github.com/pehlicd/crd-wizard/internal/web"
@@ -30,46 +38,59 @@ import (
 
 // Configuration variables bound to flags
 var (
-	port string
+	host              string
+	port              string
+	allowPrivateFetch bool
+	fetchAllow        []string
+	fetchDeny         []string
+	instanceCountTTL  time.Duration
+	countConcurrency  int
+	webhooks          []string
+	webhookToken      string
+	aiRateLimit       int
+	openBrowser       bool
+	unixSocket        string
+	readTimeout       time.Duration
+	writeTimeout      time.Duration
+	idleTimeout       time.Duration
+	longReqTimeout    time.Duration
 )
 
 // webCmd represents the web command
 var webCmd = &cobra.Command{
-	Use:   "web",
-	Short: "Launch a web server to serve CRD data via a JSON API.",
-	Long:  `The web server exposes endpoints to list CRDs, their instances, and related events. It can be used as a backend for a graphical user interface.`,
-	Run: func(_ *cobra.Command, _ []string) {
+	Use:     "web",
+	Short:   "Launch a web server to serve CRD data via a JSON API.",
+	Long:    `The web server exposes endpoints to list CRDs, their instances, and related events. It can be used as a backend for a graphical user interface.`,
+	PreRunE: validateAIFlags,
+	Run: func(cmd *cobra.Command, _ []string) {
 		log := logger.NewLogger(logFormat, logLevel, os.Stderr)
 
-		clusterManager, err := k8s.NewClusterManager(kubeconfig, log)
+		clusterManager, err := k8s.NewClusterManager(kubeconfig, clientOptions(), log)
 		if err != nil {
 			log.Error("unable to create cluster manager", "err", err)
 			os.Exit(1)
 		}
 
+		allowPrefixes, err := giturl.ParsePrefixes(fetchAllow)
+		if err != nil {
+			log.Error("invalid --fetch-allow", "err", err)
+			os.Exit(1)
+		}
+		denyPrefixes, err := giturl.ParsePrefixes(fetchDeny)
+		if err != nil {
+			log.Error("invalid --fetch-deny", "err", err)
+			os.Exit(1)
+		}
+
 		var aiClient *ai.Client
 
 		if enableAI {
-			// Construct the AI Config from flags
-			aiConfig := ai.Config{
-				Provider:        ai.Provider(aiProvider),
-				Model:           aiModel,
-				OllamaHost:      ollamaHost,
-				RequestTimeout:  time.Duration(requestTimeout) * time.Minute,
-				OllamaNumCtx:    ollamaNumCtx,
-				OllamaKeepAlive: ollamaKeepAlive,
-				EnableCache:     enableCache,
-
-				// Search Configuration
-				EnableSearch:   enableSearch,
-				SearchProvider: ai.SearchProvider(searchProvider),
-				GoogleAPIKey:   googleAPIKey,
-				GoogleCX:       googleCX,
-				GeminiAPIKey:   geminiAPIKey,
-			}
-
 			// AI client needs a single K8s client for context fetching, use current
-			aiClient = ai.NewClient(aiConfig, clusterManager.GetCurrentClient(), log)
+			aiClient = ai.NewClient(aiConfig(), clusterManager.GetCurrentClient(), log)
+			if err := aiClient.EnsureModel(cmd.Context(), func(msg string) { log.Info("preparing AI model", "status", msg) }); err != nil {
+				log.Error("AI model is not available", "err", err)
+				os.Exit(1)
+			}
 
 			log.Info("AI features enabled",
 				"provider", aiProvider,
@@ -80,9 +101,56 @@ var webCmd = &cobra.Command{
 			)
 		}
 
-		server := web.NewServer(clusterManager, port, aiClient, log)
-		log.Info("starting web server", "port", port, "clusters", clusterManager.ClusterCount())
-		if err := server.Start(); err != nil {
+		opts := web.Options{
+			ExportConcurrency:  exportConcurrency,
+			AllowPrivateFetch:  allowPrivateFetch,
+			FetchAllow:         allowPrefixes,
+			FetchDeny:          denyPrefixes,
+			InstanceCountTTL:   instanceCountTTL,
+			CountConcurrency:   countConcurrency,
+			AIRateLimit:        aiRateLimit,
+			UnixSocket:         unixSocket,
+			ReadTimeout:        readTimeout,
+			WriteTimeout:       writeTimeout,
+			IdleTimeout:        idleTimeout,
+			LongRequestTimeout: longReqTimeout,
+			Build:              buildInfo(),
+			WebhookToken:       webhookToken,
+		}
+		if openBrowser && unixSocket != "" {
+			log.Warn("--open is ignored when listening on a Unix socket")
+		} else if openBrowser {
+			opts.OnListen = func(addr net.Addr) {
+				url := browserURL(addr)
+				log.Info("opening browser", "url", url)
+				if err := openURL(url); err != nil {
+					log.Warn("unable to open browser", "url", url, "err", err)
+				}
+			}
+		}
+
+		server := web.NewServer(clusterManager, host, port, aiClient, opts, log)
+		for _, webhook := range webhooks {
+			crdName, url, ok := strings.Cut(webhook, "=")
+			if !ok || crdName == "" || url == "" {
+				log.Error("invalid --webhook, expected <crd-name>=<url>", "webhook", webhook)
+				os.Exit(1)
+			}
+			// Webhooks given on the command line are trusted to target private addresses.
+			if _, err := server.AddWebhook(clusterManager.GetCurrentClient(), crdName, url, true); err != nil {
+				log.Error("unable to register webhook", "crd", crdName, "err", err)
+				os.Exit(1)
+			}
+		}
+		if unixSocket != "" {
+			log.Info("starting web server", "socket", unixSocket, "contexts", clusterManager.ContextCount())
+		} else {
+			log.Info("starting web server", "host", host, "port", port, "contexts", clusterManager.ContextCount())
+		}
+		// Stop gracefully on interrupt so that a Unix socket file is cleaned up.
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := server.Start(ctx); err != nil {
 			log.Error("error starting web server", "err", err)
 			os.Exit(1)
 		}
@@ -91,7 +159,57 @@ var webCmd = &cobra.Command{
 
 func init() {
 	// Server Flags
+	webCmd.Flags().StringVar(&host, "host", "localhost", "Host or interface address the web server binds to (use 0.0.0.0 to listen on all interfaces)")
 	webCmd.Flags().StringVarP(&port, "port", "p", "8080", "Port for the web server")
+	webCmd.Flags().StringVar(&unixSocket, "unix-socket", "", "Listen on this Unix domain socket path instead of TCP (--host and --port are ignored)")
+	webCmd.Flags().DurationVar(&readTimeout, "read-timeout", web.DefaultReadTimeout, "Maximum duration for reading an entire request, including the body")
+	webCmd.Flags().DurationVar(&writeTimeout, "write-timeout", web.DefaultWriteTimeout, "Maximum duration for writing a response of regular API routes")
+	webCmd.Flags().DurationVar(&idleTimeout, "idle-timeout", web.DefaultIdleTimeout, "Maximum time to wait for the next request on a keep-alive connection")
+	webCmd.Flags().DurationVar(&longReqTimeout, "long-request-timeout", web.DefaultLongRequestTimeout, "Maximum duration for writing a response of AI generation and export routes")
+	webCmd.Flags().IntVar(&exportConcurrency, "concurrency", 5, "Number of CRDs to fetch and render concurrently when exporting all CRDs")
+	webCmd.Flags().IntVar(&countConcurrency, "count-concurrency", 10, "Number of CRDs whose instances are counted concurrently when listing CRDs")
+	webCmd.Flags().DurationVar(&instanceCountTTL, "instance-count-cache-ttl", 30*time.Second, "How long instance counts are cached before being refreshed in the background (0 disables the cache)")
+	webCmd.Flags().IntVar(&aiRateLimit, "ai-rate-limit", 10, "Maximum AI generation requests per minute for each client IP (0 disables the limit)")
+	webCmd.Flags().StringArrayVar(&webhooks, "webhook", nil, "Post a JSON event to a URL whenever an instance of a CRD in the current cluster changes, as <crd-name>=<url> (repeatable)")
+	webCmd.Flags().StringVar(&webhookToken, "webhook-token", "", "Require this bearer token to list, register or remove webhooks through the API (defaults to the CRD_WIZARD_WEBHOOK_TOKEN environment variable)")
+	webCmd.Flags().BoolVar(&openBrowser, "open", false, "Open the web UI in the default browser once the server is listening")
+	webCmd.Flags().BoolVar(&allowPrivateFetch, "allow-private-fetch", false, "Allow generating docs from URLs that resolve to private, loopback or link-local addresses")
+	webCmd.Flags().StringSliceVar(&fetchAllow, "fetch-allow", nil, "Addresses or CIDR prefixes that doc generation and webhooks may reach even without --allow-private-fetch (comma-separated or repeatable)")
+	webCmd.Flags().StringSliceVar(&fetchDeny, "fetch-deny", nil, "Addresses or CIDR prefixes that doc generation and webhooks never reach, even with --allow-private-fetch (comma-separated or repeatable)")
 
 	rootCmd.AddCommand(webCmd)
 }
+
+// browserURL returns the URL of the web UI served on addr. Unspecified addresses
+// are replaced with localhost since browsers cannot connect to them.
+func browserURL(addr net.Addr) string {
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return "http://" + addr.String()
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
+
+// openURL opens url in the default browser of the platform.
+func openURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	case "linux", "freebsd", "openbsd", "netbsd":
+		cmd = exec.Command("xdg-open", url)
+	default:
+		return fmt.Errorf("opening a browser is not supported on %s", runtime.GOOS)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Reap the process without blocking the server.
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
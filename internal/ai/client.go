@@ -2,6 +2,8 @@ package ai
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,10 +15,11 @@ import (
 	"time"
 
 	"golang.org/x/sync/errgroup"
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 
 	"github.com/pehlicd/crd-wizard/internal/k8s"
 	"github.com/pehlicd/crd-wizard/internal/logger"
+	"github.com/pehlicd/crd-wizard/internal/skeleton"
 )
 
 const (
@@ -63,7 +66,7 @@ func NewClient(c Config, kubeClient *k8s.Client, l *logger.Logger) *Client {
 		provider = NewOllamaProvider(c, httpClient)
 	case ProviderGemini:
 		var err error
-		provider, err = NewGeminiProvider(context.Background(), c.GeminiAPIKey, c.Model)
+		provider, err = NewGeminiProvider(context.Background(), c.GeminiAPIKey, c.Model, c.MaxOutputTokens)
 		if err != nil {
 			l.Warn("failed to initialize gemini provider, falling back to ollama", "err", err)
 			provider = NewOllamaProvider(c, httpClient)
@@ -90,20 +93,168 @@ func NewClient(c Config, kubeClient *k8s.Client, l *logger.Logger) *Client {
 	return client
 }
 
+// ValidationAttempt records the outcome of validating one generated response.
+type ValidationAttempt struct {
+	Attempt int `json:"attempt"`
+	// Error is the validation error, empty when the attempt passed validation.
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// GenerationResult is the generated documentation together with the validation history
+// that produced it.
+type GenerationResult struct {
+	Content string `json:"content"`
+	// Cached is set when the content was served from the cache, in which case no attempts were made.
+	Cached   bool                `json:"cached"`
+	Attempts []ValidationAttempt `json:"attempts"`
+}
+
+// GenerateOptions adjusts a single generation, overriding the client configuration.
+type GenerateOptions struct {
+	// SkipSearch leaves out the web search, even when Config.EnableSearch is set.
+	SkipSearch bool
+	// Prompt is sent instead of building a new one, e.g. the Estimate.Prompt the user confirmed,
+	// so that the live examples and web search results are not fetched a second time.
+	Prompt string
+}
+
 // GenerateCrdContext performs the full RAG pipeline to generate documentation for a CRD.
-func (c *Client) GenerateCrdContext(ctx context.Context, group, version, kind, schemaJSON string) (string, error) {
+func (c *Client) GenerateCrdContext(ctx context.Context, group, version, kind, schemaJSON string, opts GenerateOptions) (string, error) {
+	result, err := c.GenerateCrdContextVerbose(ctx, group, version, kind, schemaJSON, opts)
+	if err != nil {
+		return "", err
+	}
+	return result.Content, nil
+}
+
+// GenerateCrdContextVerbose is like GenerateCrdContext but also returns the outcome of
+// every validation attempt, which helps to understand why generation struggled.
+func (c *Client) GenerateCrdContextVerbose(ctx context.Context, group, version, kind, schemaJSON string, opts GenerateOptions) (*GenerationResult, error) {
 	// 1. Check Cache (Fast Path)
-	cacheKey := fmt.Sprintf("%s/%s/%s", group, version, kind)
-	if c.Config.EnableCache {
-		c.cacheMu.RLock()
-		val, found := c.cache[cacheKey]
-		c.cacheMu.RUnlock()
-		if found {
-			c.log.Info("Serving CRD documentation from cache", "key", cacheKey)
-			return val, nil
+	cacheKey := c.cacheKey(group, version, kind, opts)
+	if val, found := c.cached(cacheKey); found {
+		c.log.Info("Serving CRD documentation from cache", "key", cacheKey)
+		return &GenerationResult{Content: val, Cached: true, Attempts: []ValidationAttempt{}}, nil
+	}
+
+	basePrompt := opts.Prompt
+	var err error
+	if basePrompt == "" {
+		if basePrompt, err = c.buildPrompt(ctx, group, version, kind, schemaJSON, opts); err != nil {
+			return nil, err
 		}
 	}
 
+	currentPrompt := basePrompt
+	var finalResponse string
+	attempts := make([]ValidationAttempt, 0, c.Config.MaxValidationRetries+1)
+
+	// Providers that support chat get corrections as follow-up turns of one conversation.
+	chatProvider, isChat := c.Provider.(ChatProvider)
+	history := []Message{{Role: "user", Content: basePrompt}}
+
+	totalInferenceStart := time.Now()
+
+	for attempt := 0; attempt <= c.Config.MaxValidationRetries; attempt++ {
+		attemptStart := time.Now()
+		c.log.Info("generating response from AI provider", "provider", c.Provider.Name(), "attempt", attempt+1)
+
+		var response string
+		if isChat {
+			response, err = chatProvider.Chat(ctx, history)
+		} else {
+			response, err = c.Provider.Generate(ctx, currentPrompt)
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.log.Info("inference generation completed", "duration", time.Since(attemptStart))
+
+		// Validation Step
+		c.log.Info("validating generated example via dry-run")
+		validationErr := c.validateGeneratedContent(ctx, response)
+		record := ValidationAttempt{Attempt: attempt + 1, DurationMs: time.Since(attemptStart).Milliseconds()}
+		if validationErr != nil {
+			record.Error = validationErr.Error()
+		}
+		attempts = append(attempts, record)
+		if validationErr == nil {
+			c.log.Info("validation successful", "attempt_duration", time.Since(attemptStart))
+			finalResponse = response
+			break
+		}
+
+		c.log.Warn("validation failed", "err", validationErr, "attempt_duration", time.Since(attemptStart))
+
+		// If this was the last attempt, return the best we have (or error out)
+		if attempt == c.Config.MaxValidationRetries {
+			c.log.Warn("max retries reached, returning last response despite validation errors")
+			finalResponse = response
+			// Optional: append a warning to the final response
+			finalResponse += fmt.Sprintf("\n\n> **Warning:** Automatic validation failed: %v", validationErr)
+			break
+		}
+
+		// Update prompt for next iteration with the error
+		if isChat {
+			history = append(history,
+				Message{Role: "assistant", Content: response},
+				Message{Role: "user", Content: buildCorrectionFollowUp(validationErr.Error())},
+			)
+		} else {
+			currentPrompt = c.buildCorrectionPrompt(basePrompt, response, validationErr.Error())
+		}
+	}
+
+	c.log.Info("total generation pipeline completed", "total_duration", time.Since(totalInferenceStart))
+
+	if c.Config.EnableCache && finalResponse != "" {
+		c.cacheMu.Lock()
+		c.cache[cacheKey] = finalResponse
+		c.cacheMu.Unlock()
+	}
+
+	return &GenerationResult{Content: finalResponse, Attempts: attempts}, nil
+}
+
+// cacheKey returns the key generated documentation is cached under. Besides the CRD it
+// covers everything that changes the answer: the provider and model, whether the web is
+// searched, and the prompt template, which depends on the configured language.
+func (c *Client) cacheKey(group, version, kind string, opts GenerateOptions) string {
+	return fmt.Sprintf("%s/%s/%s|%s/%s|search=%t|prompt=%s",
+		group, version, kind,
+		c.Provider.Name(), c.Config.Model,
+		c.Config.EnableSearch && !opts.SkipSearch,
+		c.promptTemplateHash(group, version, kind),
+	)
+}
+
+// promptTemplateHash hashes the prompt instructions without the schema, examples and search
+// results, so that cached answers are not reused once the prompt changes.
+func (c *Client) promptTemplateHash(group, version, kind string) string {
+	template := c.buildAugmentedPrompt(group, version, kind, "", "", "", "")
+	if ollama, ok := c.Provider.(*OllamaProvider); ok {
+		template += ollama.systemPrompt()
+	}
+	sum := sha256.Sum256([]byte(template))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cached returns the documentation cached under key, if caching is enabled and it is there.
+func (c *Client) cached(key string) (string, bool) {
+	if !c.Config.EnableCache {
+		return "", false
+	}
+	c.cacheMu.RLock()
+	defer c.cacheMu.RUnlock()
+	val, found := c.cache[key]
+	return val, found
+}
+
+// buildPrompt gathers live examples or a schema skeleton and, when enabled, web search results,
+// and combines them with the pruned schema into the generation prompt for a CRD.
+func (c *Client) buildPrompt(ctx context.Context, group, version, kind, schemaJSON string, opts GenerateOptions) (string, error) {
 	g, groupCtx := errgroup.WithContext(ctx)
 
 	var (
@@ -114,7 +265,10 @@ func (c *Client) GenerateCrdContext(ctx context.Context, group, version, kind, s
 	g.Go(func() error {
 		start := time.Now()
 		c.log.Info("retrieving live examples from cluster")
-		ex, err := c.KubeClient.FetchCRDExamples(groupCtx, group, version, kind)
+		ex, err := c.KubeClient.FetchCRDExamples(groupCtx, group, version, kind, k8s.ExampleOptions{
+			Limit:     int64(c.Config.ExampleLimit),
+			Namespace: c.Config.ExampleNamespace,
+		})
 		if err != nil {
 			c.log.Warn("failed to fetch live examples", "err", err)
 			return nil // Non-fatal
@@ -124,24 +278,16 @@ func (c *Client) GenerateCrdContext(ctx context.Context, group, version, kind, s
 		return nil
 	})
 
-	if c.Config.EnableSearch {
+	if c.Config.EnableSearch && !opts.SkipSearch {
 		g.Go(func() error {
 			start := time.Now()
 			c.log.Info(fmt.Sprintf("searching web using %s", c.Config.SearchProvider))
 			query := fmt.Sprintf("kubernetes crd %s %s %s example yaml", group, version, kind)
 
-			var res string
-			var err error
-
 			searchCtx, cancel := context.WithTimeout(groupCtx, 5*time.Second)
 			defer cancel()
 
-			if c.Config.SearchProvider == SearchProviderGoogle {
-				res, err = c.performGoogleSearch(searchCtx, query)
-			} else {
-				res, err = c.performDuckDuckGoSearch(searchCtx, query)
-			}
-
+			res, err := c.Search(searchCtx, query)
 			if err != nil {
 				c.log.Warn("web search failed", "provider", c.Config.SearchProvider, "err", err)
 				return nil
@@ -173,7 +319,9 @@ func (c *Client) GenerateCrdContext(ctx context.Context, group, version, kind, s
 	var skeletonYAML string
 	if crdExamples == "" {
 		c.log.Info("No live examples found; generating skeleton from schema.")
-		skeletonYAML, err = generateYAMLFromSchema(group, version, kind, string(prunedSchemaJSON))
+		// The unpruned schema is used so that field descriptions end up as comments in the skeleton,
+		// shortened by promptSkeleton to keep the prompt within budget.
+		skeletonYAML, err = promptSkeleton(group, version, kind, schemaJSON)
 		if err != nil {
 			c.log.Warn("Failed to generate skeleton", "err", err)
 		}
@@ -181,56 +329,8 @@ func (c *Client) GenerateCrdContext(ctx context.Context, group, version, kind, s
 
 	basePrompt := c.buildAugmentedPrompt(group, version, kind, string(prunedSchemaJSON), crdExamples, skeletonYAML, webResults)
 
-	c.log.Info("prompt constructed", "length_chars", len(basePrompt), "estimated_tokens", len(basePrompt)/4)
-
-	currentPrompt := basePrompt
-	var finalResponse string
-
-	totalInferenceStart := time.Now()
-
-	for attempt := 0; attempt <= c.Config.MaxValidationRetries; attempt++ {
-		attemptStart := time.Now()
-		c.log.Info("generating response from AI provider", "provider", c.Provider.Name(), "attempt", attempt+1)
-
-		response, err := c.Provider.Generate(ctx, currentPrompt)
-		if err != nil {
-			return "", err
-		}
-		c.log.Info("inference generation completed", "duration", time.Since(attemptStart))
-
-		// Validation Step
-		c.log.Info("validating generated example via dry-run")
-		validationErr := c.validateGeneratedContent(ctx, response)
-		if validationErr == nil {
-			c.log.Info("validation successful", "attempt_duration", time.Since(attemptStart))
-			finalResponse = response
-			break
-		}
-
-		c.log.Warn("validation failed", "err", validationErr, "attempt_duration", time.Since(attemptStart))
-
-		// If this was the last attempt, return the best we have (or error out)
-		if attempt == c.Config.MaxValidationRetries {
-			c.log.Warn("max retries reached, returning last response despite validation errors")
-			finalResponse = response
-			// Optional: append a warning to the final response
-			finalResponse += fmt.Sprintf("\n\n> **Warning:** Automatic validation failed: %v", validationErr)
-			break
-		}
-
-		// Update prompt for next iteration with the error
-		currentPrompt = c.buildCorrectionPrompt(basePrompt, response, validationErr.Error())
-	}
-
-	c.log.Info("total generation pipeline completed", "total_duration", time.Since(totalInferenceStart))
-
-	if c.Config.EnableCache && finalResponse != "" {
-		c.cacheMu.Lock()
-		c.cache[cacheKey] = finalResponse
-		c.cacheMu.Unlock()
-	}
-
-	return finalResponse, nil
+	c.log.Info("prompt constructed", "length_chars", len(basePrompt), "estimated_tokens", estimateTokens(basePrompt))
+	return basePrompt, nil
 }
 
 // validateGeneratedContent extracts YAML and calls the K8s dry-run
@@ -321,6 +421,73 @@ func (c *Client) buildCorrectionPrompt(originalPrompt, previousResponse, errorMs
 	return sb.String()
 }
 
+// buildCorrectionFollowUp asks for a fixed response in an ongoing conversation,
+// where the original request and the invalid answer are already part of the history.
+func buildCorrectionFollowUp(errorMsg string) string {
+	var sb strings.Builder
+	sb.WriteString("!!! CRITICAL: VALIDATION FAILED !!!\n")
+	sb.WriteString("The YAML you generated above was rejected by the Kubernetes API Server:\n")
+	sb.WriteString(fmt.Sprintf("`%s`\n\n", errorMsg))
+	sb.WriteString("Please regenerate the ENTIRE response. Fix the YAML to satisfy the schema and validation error above.")
+	return sb.String()
+}
+
+// Ping checks that the configured AI provider is reachable and accepts the credentials.
+func (c *Client) Ping(ctx context.Context) error {
+	pinger, ok := c.Provider.(Pinger)
+	if !ok {
+		return fmt.Errorf("provider %s does not support health checks", c.Provider.Name())
+	}
+	return pinger.Ping(ctx)
+}
+
+// EnsureModel checks that the configured model is available before the first request,
+// pulling it when Config.OllamaAutoPull is set. report receives human readable progress
+// messages. Only Ollama serves local models, other providers are left alone.
+func (c *Client) EnsureModel(ctx context.Context, report func(msg string)) error {
+	ollama, ok := c.Provider.(*OllamaProvider)
+	if !ok {
+		return nil
+	}
+	found, err := ollama.HasModel(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to reach ollama at %s: %w", c.Config.OllamaHost, err)
+	}
+	if found {
+		return nil
+	}
+	if !c.Config.OllamaAutoPull {
+		return ollama.modelNotFound()
+	}
+
+	report(fmt.Sprintf("pulling model %s", c.Config.Model))
+	lastStatus, lastPercent := "", -1
+	return ollama.Pull(ctx, func(p PullProgress) {
+		if p.Total == 0 {
+			if p.Status != lastStatus {
+				report(p.Status)
+			}
+			lastStatus, lastPercent = p.Status, -1
+			return
+		}
+		// Layers stream many updates, only report every tenth percent.
+		percent := int(p.Completed * 100 / p.Total / 10 * 10)
+		if p.Status != lastStatus || percent != lastPercent {
+			report(fmt.Sprintf("%s: %d%%", p.Status, percent))
+		}
+		lastStatus, lastPercent = p.Status, percent
+	})
+}
+
+// Search runs query against the configured search provider and returns the results
+// formatted for the prompt.
+func (c *Client) Search(ctx context.Context, query string) (string, error) {
+	if c.Config.SearchProvider == SearchProviderGoogle {
+		return c.performGoogleSearch(ctx, query)
+	}
+	return c.performDuckDuckGoSearch(ctx, query)
+}
+
 // performDuckDuckGoSearch scrapes the HTML version of DuckDuckGo (No API Key needed)
 func (c *Client) performDuckDuckGoSearch(ctx context.Context, query string) (string, error) {
 	data := url.Values{}
@@ -466,7 +633,7 @@ func (c *Client) buildAugmentedPrompt(group, version, kind, schemaJSON, examples
 		sb.WriteString("\n</live_cluster_examples>\n\n")
 	} else if skeleton != "" {
 		sb.WriteString("<schema_skeleton>\n")
-		sb.WriteString("No live examples found. Fill in this skeleton with realistic values:\n")
+		sb.WriteString("No live examples found. Fill in this skeleton with realistic values, optional fields are commented out:\n")
 		sb.WriteString(skeleton)
 		sb.WriteString("\n</schema_skeleton>\n\n")
 	}
@@ -483,7 +650,7 @@ func (c *Client) buildAugmentedPrompt(group, version, kind, schemaJSON, examples
    - Use 'kind: ` + kind + `'
    - Do NOT use placeholders like 'string' or 'value'. Use realistic defaults (e.g., port: 80, image: nginx:latest).
    - If <live_cluster_examples> are provided, prefer their configuration style.
-
+` + languageCommand(c.Config.Language) + `
 **OUTPUT FORMAT:**
 ### Explanation
 (Text here)
@@ -497,86 +664,39 @@ func (c *Client) buildAugmentedPrompt(group, version, kind, schemaJSON, examples
 	return sb.String()
 }
 
-func generateYAMLFromSchema(group, version, kind, schemaJSON string) (string, error) {
-	var schema map[string]any
-	if err := json.Unmarshal([]byte(schemaJSON), &schema); err != nil {
-		return "", fmt.Errorf("failed to unmarshal schema: %w", err)
-	}
-
-	root := make(map[string]any)
-	root["apiVersion"] = fmt.Sprintf("%s/%s", group, version)
-	root["kind"] = kind
-	root["metadata"] = map[string]any{"name": fmt.Sprintf("my-%s-demo", strings.ToLower(kind))}
+// The schema skeleton is sent along with the pruned schema, so it is kept small: descriptions
+// are cut to their first sentence and fewer levels are expanded until it fits skeletonBudget.
+const (
+	skeletonMaxDepth       = 6
+	skeletonMaxDescription = 120
+	skeletonBudget         = 8 << 10
+)
 
-	if props, ok := schema["properties"].(map[string]any); ok {
-		if specSchema, ok := props["spec"].(map[string]any); ok {
-			root["spec"] = buildObjectFromSchema(specSchema)
+// promptSkeleton returns the schema skeleton used in prompts when a CRD has no live instances.
+func promptSkeleton(group, version, kind, schemaJSON string) (string, error) {
+	for depth := skeletonMaxDepth; ; depth-- {
+		example, err := skeleton.FromSchema(group, version, kind, schemaJSON, skeleton.Options{MaxDepth: depth, MaxDescription: skeletonMaxDescription})
+		if err != nil || len(example) <= skeletonBudget || depth == 1 {
+			return example, err
 		}
 	}
-	yamlBytes, err := yaml.Marshal(root)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal skeleton: %w", err)
-	}
-	return string(yamlBytes), nil
 }
 
-func buildObjectFromSchema(schema map[string]any) map[string]any {
-	obj := make(map[string]any)
-	properties, ok := schema["properties"].(map[string]any)
-	if !ok {
-		return obj
-	}
-	requiredSet := make(map[string]struct{})
-	if required, ok := schema["required"].([]any); ok {
-		for _, req := range required {
-			if r, ok := req.(string); ok {
-				requiredSet[r] = struct{}{}
-			}
-		}
+// languageInstruction asks for prose in language, keeping manifests valid. It is empty when
+// no language is set.
+func languageInstruction(language string) string {
+	if language == "" {
+		return ""
 	}
-	for key, val := range properties {
-		if _, isRequired := requiredSet[key]; isRequired {
-			if propSchema, ok := val.(map[string]any); ok {
-				obj[key] = generateValueForSchema(key, propSchema)
-			}
-		}
-	}
-	return obj
+	return fmt.Sprintf("Write all explanations and descriptions in %s. Keep YAML keys, values, field names and code blocks unchanged and valid.", language)
 }
 
-func generateValueForSchema(key string, propSchema map[string]any) any {
-	propType, _ := propSchema["type"].(string)
-	lowerKey := strings.ToLower(key)
-
-	switch propType {
-	case "string":
-		if strings.Contains(lowerKey, "image") {
-			return "nginx:latest"
-		}
-		if strings.Contains(lowerKey, "host") {
-			return "example.com"
-		}
-		return fmt.Sprintf("example-%s", key)
-	case "integer", "number":
-		if strings.Contains(lowerKey, "port") {
-			return 8080
-		}
-		if strings.Contains(lowerKey, "replica") {
-			return 2
-		}
-		return 1
-	case "boolean":
-		return false
-	case "object":
-		return buildObjectFromSchema(propSchema)
-	case "array":
-		if items, ok := propSchema["items"].(map[string]any); ok {
-			return []any{generateValueForSchema("item", items)}
-		}
-		return []any{}
-	default:
-		return "value"
+// languageCommand is languageInstruction as an extra numbered command for the prompts.
+func languageCommand(language string) string {
+	if language == "" {
+		return ""
 	}
+	return "3. **Language**: " + languageInstruction(language) + "\n"
 }
 
 func pruneSchema(schemaJSON string) (map[string]any, error) {
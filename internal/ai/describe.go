@@ -0,0 +1,135 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxDescribedFields bounds how many fields are described in one request, so that the
+// prompt and the answer stay within the context window of small local models.
+const maxDescribedFields = 150
+
+// DescribeFields asks the AI provider for descriptions of the fields of schemaJSON that have
+// none. The result maps field paths such as spec.template.containers[].name to descriptions;
+// fields that already have a description are never included.
+func (c *Client) DescribeFields(ctx context.Context, schemaJSON string) (map[string]string, error) {
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(schemaJSON), &schema); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schema: %w", err)
+	}
+
+	var missing []string
+	collectUndescribed(schema, "", &missing)
+	if len(missing) == 0 {
+		return map[string]string{}, nil
+	}
+	sort.Strings(missing)
+	if len(missing) > maxDescribedFields {
+		c.log.Warn("too many fields without a description, only describing some of them", "fields", len(missing), "limit", maxDescribedFields)
+		missing = missing[:maxDescribedFields]
+	}
+
+	prunedSchema, err := pruneSchema(schemaJSON)
+	if err != nil {
+		return nil, fmt.Errorf("error pruning schema: %w", err)
+	}
+	prunedSchemaJSON, err := json.Marshal(prunedSchema)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling pruned schema: %w", err)
+	}
+
+	c.log.Info("describing fields", "provider", c.Provider.Name(), "fields", len(missing))
+	response, err := c.Provider.Generate(ctx, buildDescribePrompt(string(prunedSchemaJSON), missing, c.Config.Language))
+	if err != nil {
+		return nil, err
+	}
+
+	var described map[string]string
+	if err := json.Unmarshal([]byte(extractJSONObject(response)), &described); err != nil {
+		return nil, fmt.Errorf("failed to parse field descriptions: %w", err)
+	}
+
+	// Keep only the fields that were asked for, models sometimes rename or invent paths.
+	descriptions := make(map[string]string, len(missing))
+	for _, path := range missing {
+		if desc := strings.TrimSpace(described[path]); desc != "" {
+			descriptions[path] = desc
+		}
+	}
+	return descriptions, nil
+}
+
+// collectUndescribed appends the paths of the fields below schema that have no description.
+// Array items are addressed as name[]; the fields every resource has are skipped at the root.
+func collectUndescribed(schema map[string]any, prefix string, paths *[]string) {
+	properties, _ := schema["properties"].(map[string]any)
+	for name, value := range properties {
+		prop, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		root := prefix == ""
+		if root && (name == "apiVersion" || name == "kind") {
+			continue
+		}
+
+		// spec, status and metadata are documented as sections rather than fields.
+		section := root && (name == "spec" || name == "status" || name == "metadata")
+		if desc, _ := prop["description"].(string); !section && strings.TrimSpace(desc) == "" {
+			*paths = append(*paths, path)
+		}
+
+		if items, ok := prop["items"].(map[string]any); ok {
+			collectUndescribed(items, path+"[]", paths)
+		} else {
+			collectUndescribed(prop, path, paths)
+		}
+	}
+}
+
+// extractJSONObject returns the outermost JSON object in content, which models tend to wrap
+// in code fences or prose.
+func extractJSONObject(content string) string {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start == -1 || end < start {
+		return content
+	}
+	return content[start : end+1]
+}
+
+func buildDescribePrompt(schemaJSON string, paths []string, language string) string {
+	var sb strings.Builder
+
+	sb.WriteString("The following Kubernetes CustomResourceDefinition schema has fields without a description.\n\n")
+
+	sb.WriteString("<openapi_schema>\n")
+	sb.WriteString(schemaJSON)
+	sb.WriteString("\n</openapi_schema>\n\n")
+
+	sb.WriteString("<fields>\n")
+	for _, path := range paths {
+		sb.WriteString(path)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("</fields>\n\n")
+
+	sb.WriteString(`
+**COMMANDS:**
+1. Write a description of one or two sentences for every field listed in <fields>, based on its name, type and position in the schema.
+2. Describe what the field configures, not its type. Do not invent defaults or allowed values.
+` + languageCommand(language) + `
+**OUTPUT FORMAT:**
+A single JSON object mapping each field path exactly as listed to its description, and nothing else.
+`)
+
+	return sb.String()
+}
@@ -0,0 +1,121 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ollamaDefaultNumCtx is the context window Ollama uses when --ollama-num-ctx is not set.
+const ollamaDefaultNumCtx = 4096
+
+// modelPrice is the list price of a hosted model in US dollars per million tokens.
+type modelPrice struct {
+	Input  float64
+	Output float64
+}
+
+// modelPrices holds rough list prices of paid models, matched by the longest model name prefix.
+// They only serve estimates and drift as providers change their pricing.
+var modelPrices = map[Provider]map[string]modelPrice{
+	ProviderGemini: {
+		"gemini-2.5-pro":        {Input: 1.25, Output: 10},
+		"gemini-2.5-flash":      {Input: 0.30, Output: 2.50},
+		"gemini-2.5-flash-lite": {Input: 0.10, Output: 0.40},
+		"gemini-2.0-flash":      {Input: 0.10, Output: 0.40},
+		"gemini-2.0-flash-lite": {Input: 0.075, Output: 0.30},
+		"gemini-1.5-pro":        {Input: 1.25, Output: 5},
+		"gemini-1.5-flash":      {Input: 0.075, Output: 0.30},
+	},
+}
+
+// Estimate is the approximate size and cost of generating documentation for a CRD.
+type Estimate struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	// Cached is set when the documentation is cached, so generating it costs nothing.
+	Cached       bool `json:"cached"`
+	PromptTokens int  `json:"promptTokens"`
+	// MaxOutputTokens is the configured limit of one response, 0 when the model default applies.
+	MaxOutputTokens int `json:"maxOutputTokens"`
+	// MaxAttempts is how often the prompt is sent at most, when validation keeps failing.
+	MaxAttempts int `json:"maxAttempts"`
+	// CostUSD is the rough cost of one attempt with a response of MaxOutputTokens. It is nil
+	// for local providers and for models without known pricing.
+	CostUSD  *float64 `json:"costUSD,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	// Prompt is the estimated prompt, which GenerateOptions.Prompt sends as it is.
+	Prompt string `json:"-"`
+}
+
+// estimateTokens approximates the token count of text at four characters per token.
+func estimateTokens(text string) int {
+	return len(text) / 4
+}
+
+// EstimateCrdContext builds the prompt GenerateCrdContext would send, including live examples
+// and web search results, and reports its approximate size and cost without running it.
+func (c *Client) EstimateCrdContext(ctx context.Context, group, version, kind, schemaJSON string, opts GenerateOptions) (*Estimate, error) {
+	estimate := &Estimate{
+		Provider:    c.Provider.Name(),
+		Model:       c.Config.Model,
+		MaxAttempts: c.Config.MaxValidationRetries + 1,
+	}
+	if _, found := c.cached(c.cacheKey(group, version, kind, opts)); found {
+		estimate.Cached = true
+		return estimate, nil
+	}
+
+	prompt, err := c.buildPrompt(ctx, group, version, kind, schemaJSON, opts)
+	if err != nil {
+		return nil, err
+	}
+	estimate.Prompt = prompt
+	estimate.PromptTokens = estimateTokens(prompt)
+
+	switch p := c.Provider.(type) {
+	case *OllamaProvider:
+		estimate.PromptTokens += estimateTokens(p.systemPrompt())
+		estimate.MaxOutputTokens = c.Config.OllamaNumPredict
+
+		numCtx := c.Config.OllamaNumCtx
+		if numCtx == 0 {
+			numCtx = ollamaDefaultNumCtx
+		}
+		if estimate.PromptTokens+estimate.MaxOutputTokens > numCtx {
+			estimate.Warnings = append(estimate.Warnings, fmt.Sprintf(
+				"the prompt (~%d tokens) and response (up to %d tokens) exceed the context window of %d tokens, raise --ollama-num-ctx or the prompt gets truncated",
+				estimate.PromptTokens, estimate.MaxOutputTokens, numCtx))
+		}
+	default:
+		estimate.MaxOutputTokens = c.Config.MaxOutputTokens
+		if prices, paid := modelPrices[c.Config.Provider]; paid {
+			if price, ok := priceOf(prices, c.Config.Model); ok {
+				cost := (float64(estimate.PromptTokens)*price.Input + float64(estimate.MaxOutputTokens)*price.Output) / 1e6
+				estimate.CostUSD = &cost
+			} else {
+				estimate.Warnings = append(estimate.Warnings, fmt.Sprintf("no pricing known for model %s, the cost cannot be estimated", c.Config.Model))
+			}
+		}
+	}
+
+	if estimate.CostUSD != nil && estimate.MaxAttempts > 1 {
+		estimate.Warnings = append(estimate.Warnings, fmt.Sprintf("failed validations are retried up to %d times, each retry costs about as much again", estimate.MaxAttempts-1))
+	}
+	return estimate, nil
+}
+
+// priceOf returns the price of the longest model name prefix of model in prices.
+func priceOf(prices map[string]modelPrice, model string) (modelPrice, bool) {
+	var (
+		best    modelPrice
+		bestLen int
+	)
+	model = strings.TrimPrefix(model, "models/")
+	for prefix, price := range prices {
+		if strings.HasPrefix(model, prefix) && len(prefix) > bestLen {
+			best, bestLen = price, len(prefix)
+		}
+	}
+	return best, bestLen > 0
+}
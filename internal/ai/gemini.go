@@ -11,9 +11,11 @@ import (
 type GeminiProvider struct {
 	client *genai.Client
 	model  string
+	// maxOutputTokens limits the length of the response, zero uses the model default.
+	maxOutputTokens int
 }
 
-func NewGeminiProvider(ctx context.Context, apiKey, model string) (*GeminiProvider, error) {
+func NewGeminiProvider(ctx context.Context, apiKey, model string, maxOutputTokens int) (*GeminiProvider, error) {
 	if model == "" {
 		model = "gemini-1.5-flash"
 	}
@@ -26,8 +28,9 @@ func NewGeminiProvider(ctx context.Context, apiKey, model string) (*GeminiProvid
 	}
 
 	return &GeminiProvider{
-		client: client,
-		model:  model,
+		client:          client,
+		model:           model,
+		maxOutputTokens: maxOutputTokens,
 	}, nil
 }
 
@@ -35,8 +38,21 @@ func (p *GeminiProvider) Name() string {
 	return "gemini"
 }
 
+// Ping checks the API key by looking up the configured model.
+func (p *GeminiProvider) Ping(ctx context.Context) error {
+	if _, err := p.client.Models.Get(ctx, p.model, nil); err != nil {
+		return fmt.Errorf("gemini model lookup failed: %w", err)
+	}
+	return nil
+}
+
 func (p *GeminiProvider) Generate(ctx context.Context, prompt string) (string, error) {
-	resp, err := p.client.Models.GenerateContent(ctx, p.model, genai.Text(prompt), nil)
+	var config *genai.GenerateContentConfig
+	if p.maxOutputTokens > 0 {
+		config = &genai.GenerateContentConfig{MaxOutputTokens: int32(p.maxOutputTokens)} //nolint:gosec // bounded by the flag value
+	}
+
+	resp, err := p.client.Models.GenerateContent(ctx, p.model, genai.Text(prompt), config)
 	if err != nil {
 		return "", fmt.Errorf("gemini generation failed: %w", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,8 @@ import (
 
 const (
 	maxScannerCapacity = 2 * 1024 * 1024 // Increased buffer for larger responses
+
+	ollamaSystemPrompt = "You are a Senior Kubernetes Engineer. Your output must be technical, precise, and valid YAML. Do not chat. Do not provide preamble like 'Here is the file'. Output Markdown only."
 )
 
 type OllamaProvider struct {
@@ -31,8 +34,144 @@ func (p *OllamaProvider) Name() string {
 	return string(ProviderOllama)
 }
 
+// Ping checks that the Ollama server is reachable and has the configured model.
+func (p *OllamaProvider) Ping(ctx context.Context) error {
+	found, err := p.HasModel(ctx)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return p.modelNotFound()
+	}
+	return nil
+}
+
+// HasModel reports whether the configured model is available on the Ollama server.
+func (p *OllamaProvider) HasModel(ctx context.Context) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.Config.OllamaHost+"/api/tags", nil)
+	if err != nil {
+		return false, fmt.Errorf("error creating request: %w", err)
+	}
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("error sending request to ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("ollama returned status %d", resp.StatusCode)
+	}
+
+	var tags struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return false, fmt.Errorf("error decoding ollama models: %w", err)
+	}
+	for _, m := range tags.Models {
+		// Models pulled without a tag are listed with the implicit "latest" tag.
+		if m.Name == p.Config.Model || m.Name == p.Config.Model+":latest" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// PullProgress is a status update streamed by Ollama while pulling a model.
+type PullProgress struct {
+	Status string `json:"status"`
+	// Total and Completed are the size and downloaded bytes of the current layer, zero
+	// for steps that do not download anything.
+	Total     int64  `json:"total"`
+	Completed int64  `json:"completed"`
+	Error     string `json:"error"`
+}
+
+// Pull downloads the configured model, calling progress for every streamed status update.
+func (p *OllamaProvider) Pull(ctx context.Context, progress func(PullProgress)) error {
+	jsonPayload, err := json.Marshal(map[string]any{"model": p.Config.Model, "stream": true})
+	if err != nil {
+		return fmt.Errorf("error marshalling payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.Config.OllamaHost+"/api/pull", bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Pulling can take far longer than the request timeout, so only ctx bounds it.
+	client := &http.Client{Transport: p.HTTPClient.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request to ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("ollama pull failed (%d): %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var update PullProgress
+		if err := json.Unmarshal(scanner.Bytes(), &update); err != nil {
+			continue
+		}
+		if update.Error != "" {
+			return fmt.Errorf("ollama pull failed: %s", update.Error)
+		}
+		if progress != nil {
+			progress(update)
+		}
+		if update.Status == "success" {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading stream: %w", err)
+	}
+	return fmt.Errorf("ollama pull of %s ended without success", p.Config.Model)
+}
+
+// ErrModelNotFound is returned when Ollama does not have the configured model.
+var ErrModelNotFound = errors.New("model not found")
+
+// modelNotFound wraps ErrModelNotFound with the model name and how to get it.
+func (p *OllamaProvider) modelNotFound() error {
+	return fmt.Errorf("%s: %w, run `ollama pull %s` or pass --ollama-auto-pull", p.Config.Model, ErrModelNotFound, p.Config.Model)
+}
+
 // Generate handles the raw HTTP interaction with Ollama
 func (p *OllamaProvider) Generate(ctx context.Context, prompt string) (string, error) {
+	payload := p.basePayload()
+	payload["prompt"] = prompt
+	payload["system"] = p.systemPrompt()
+
+	return p.stream(ctx, "/api/generate", payload)
+}
+
+// Chat sends the conversation to Ollama's chat endpoint, so the model keeps the
+// earlier turns as context instead of receiving one ever-growing prompt.
+func (p *OllamaProvider) Chat(ctx context.Context, messages []Message) (string, error) {
+	payload := p.basePayload()
+	payload["messages"] = append([]Message{{Role: "system", Content: p.systemPrompt()}}, messages...)
+
+	return p.stream(ctx, "/api/chat", payload)
+}
+
+// systemPrompt returns the system prompt, asking for prose in Config.Language when it is set.
+func (p *OllamaProvider) systemPrompt() string {
+	if instruction := languageInstruction(p.Config.Language); instruction != "" {
+		return ollamaSystemPrompt + " " + instruction
+	}
+	return ollamaSystemPrompt
+}
+
+// basePayload returns the request fields shared by the generate and chat endpoints.
+func (p *OllamaProvider) basePayload() map[string]any {
 	options := map[string]any{
 		"temperature": 0.2,
 		"top_p":       0.9,
@@ -40,11 +179,12 @@ func (p *OllamaProvider) Generate(ctx context.Context, prompt string) (string, e
 	if p.Config.OllamaNumCtx > 0 {
 		options["num_ctx"] = p.Config.OllamaNumCtx
 	}
+	if p.Config.OllamaNumPredict > 0 {
+		options["num_predict"] = p.Config.OllamaNumPredict
+	}
 
 	payload := map[string]any{
 		"model":   p.Config.Model,
-		"prompt":  prompt,
-		"system":  "You are a Senior Kubernetes Engineer. Your output must be technical, precise, and valid YAML. Do not chat. Do not provide preamble like 'Here is the file'. Output Markdown only.",
 		"stream":  true,
 		"options": options,
 	}
@@ -52,13 +192,17 @@ func (p *OllamaProvider) Generate(ctx context.Context, prompt string) (string, e
 	if p.Config.OllamaKeepAlive != "" {
 		payload["keep_alive"] = p.Config.OllamaKeepAlive
 	}
+	return payload
+}
 
+// stream posts the payload to an Ollama endpoint and collects the streamed response.
+func (p *OllamaProvider) stream(ctx context.Context, endpoint string, payload map[string]any) (string, error) {
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
 		return "", fmt.Errorf("error marshalling payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", p.Config.OllamaHost+"/api/generate", bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(ctx, "POST", p.Config.OllamaHost+endpoint, bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return "", fmt.Errorf("error creating request: %w", err)
 	}
@@ -70,6 +214,9 @@ func (p *OllamaProvider) Generate(ctx context.Context, prompt string) (string, e
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return "", p.modelNotFound()
+	}
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		return "", fmt.Errorf("ollama request failed (%d): %s", resp.StatusCode, string(bodyBytes))
@@ -91,14 +238,17 @@ func (p *OllamaProvider) processStreamingResponse(body io.Reader) (string, error
 		if len(line) == 0 {
 			continue
 		}
+		// /api/generate streams "response" chunks, /api/chat streams "message" chunks.
 		var streamResp struct {
-			Response string `json:"response"`
-			Done     bool   `json:"done"`
+			Response string  `json:"response"`
+			Message  Message `json:"message"`
+			Done     bool    `json:"done"`
 		}
 		if err := json.Unmarshal(line, &streamResp); err != nil {
 			continue
 		}
 		fullResponse.WriteString(streamResp.Response)
+		fullResponse.WriteString(streamResp.Message.Content)
 		if streamResp.Done {
 			break
 		}
@@ -2,6 +2,8 @@ package ai
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 )
 
@@ -13,6 +15,28 @@ type LLMProvider interface {
 	Name() string
 }
 
+// Message is a single turn of a chat conversation.
+type Message struct {
+	Role    string `json:"role"` // "user" or "assistant"
+	Content string `json:"content"`
+}
+
+// ChatProvider is implemented by providers that support multi-turn conversations.
+// Follow-up prompts are sent as new turns instead of re-sending the whole prompt.
+type ChatProvider interface {
+	LLMProvider
+	// Chat sends the conversation so far and returns the next assistant message.
+	Chat(ctx context.Context, messages []Message) (string, error)
+}
+
+// Pinger is implemented by providers that can check their endpoint and credentials
+// without generating anything.
+type Pinger interface {
+	LLMProvider
+	// Ping returns an error when the provider cannot serve requests for the configured model.
+	Ping(ctx context.Context) error
+}
+
 type Provider string
 
 const (
@@ -25,6 +49,9 @@ const (
 type Config struct {
 	Provider Provider
 	Model    string
+	// Language the explanations and field descriptions are written in, e.g. "German" or "de".
+	// Empty leaves it to the model, which answers in English. YAML is never translated.
+	Language string
 
 	// Generic Timeouts
 	RequestTimeout time.Duration
@@ -32,13 +59,20 @@ type Config struct {
 	OllamaHost string
 
 	// Performance Configuration
-	OllamaNumCtx    int    // Context window size (e.g., 4096)
-	OllamaKeepAlive string // Duration to keep model loaded (e.g., "5m")
-	EnableCache     bool   // Toggle in-memory caching
+	OllamaNumCtx     int    // Context window size (e.g., 4096)
+	OllamaKeepAlive  string // Duration to keep model loaded (e.g., "5m")
+	OllamaNumPredict int    // Maximum number of tokens to generate (0 uses the model default)
+	MaxOutputTokens  int    // Maximum number of output tokens for hosted providers such as Gemini
+	EnableCache      bool   // Toggle in-memory caching
+	OllamaAutoPull   bool   // Pull the model at startup when Ollama does not have it
 
 	// Validation Configuration
 	MaxValidationRetries int // How many times to retry if dry-run fails (suggest 3)
 
+	// Live Example Configuration
+	ExampleLimit     int    // Number of live instances sampled as examples (0 uses the default)
+	ExampleNamespace string // Namespace to sample live examples from (empty means all namespaces)
+
 	// Search Configuration
 	EnableSearch   bool
 	SearchProvider SearchProvider // "google" or "ddg"
@@ -55,3 +89,44 @@ const (
 	SearchProviderGoogle     SearchProvider = "google"
 	SearchProviderDuckDuckGo SearchProvider = "ddg"
 )
+
+// Validate reports missing or conflicting settings for the chosen AI and search providers,
+// so that misconfigurations surface at startup rather than in the middle of a generation.
+func (c Config) Validate() error {
+	var errs []error
+
+	switch c.Provider {
+	case ProviderOllama, "":
+		if c.OllamaHost == "" {
+			errs = append(errs, errors.New("--ollama-host is required for the ollama provider"))
+		}
+	case ProviderGemini:
+		if c.GeminiAPIKey == "" {
+			errs = append(errs, errors.New("--gemini-api-key (or GEMINI_API_KEY) is required for the gemini provider"))
+		}
+	case ProviderOpenAI, ProviderAnthropic:
+		errs = append(errs, fmt.Errorf("AI provider %q is not supported yet, use ollama or gemini", c.Provider))
+	default:
+		errs = append(errs, fmt.Errorf("unknown AI provider %q, use ollama or gemini", c.Provider))
+	}
+	if c.Model == "" {
+		errs = append(errs, errors.New("--ai-model must not be empty"))
+	}
+
+	if c.EnableSearch {
+		switch c.SearchProvider {
+		case SearchProviderDuckDuckGo, "":
+		case SearchProviderGoogle:
+			if c.GoogleAPIKey == "" {
+				errs = append(errs, errors.New("--google-api-key (or GOOGLE_API_KEY) is required for the google search provider"))
+			}
+			if c.GoogleCX == "" {
+				errs = append(errs, errors.New("--google-cx (or GOOGLE_CX) is required for the google search provider"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("unknown search provider %q, use ddg or google", c.SearchProvider))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// tuiFile is the name of the file the TUI state is persisted to inside Dir.
+const tuiFile = "tui.yaml"
+
+// MaxRecent is the number of recently viewed CRDs and instances that are remembered.
+const MaxRecent = 20
+
+// Dir returns the directory crd-wizard keeps its user configuration in.
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine user config directory: %w", err)
+	}
+	return filepath.Join(dir, "crd-wizard"), nil
+}
+
+// TUI is the state of the terminal UI that is kept between sessions.
+type TUI struct {
+	// Bookmarks are the full names of the bookmarked CRDs.
+	Bookmarks []string `yaml:"bookmarks,omitempty"`
+	// Recent lists the most recently viewed CRDs and instances, newest first.
+	Recent []RecentItem `yaml:"recent,omitempty"`
+
+	path string
+}
+
+// RecentItem is a CRD or, when Name is set, a CRD instance that was viewed in the TUI.
+type RecentItem struct {
+	Cluster   string `yaml:"cluster"`
+	CRD       string `yaml:"crd"`
+	Namespace string `yaml:"namespace,omitempty"`
+	Name      string `yaml:"name,omitempty"`
+}
+
+// LoadTUI reads the TUI state from the config directory. A missing file yields an empty state.
+// If the file cannot be read or parsed, an empty state that is never saved is returned along
+// with the error, so the TUI can still start without overwriting the broken file.
+func LoadTUI() (*TUI, error) {
+	dir, err := Dir()
+	if err != nil {
+		return &TUI{}, err
+	}
+	path := filepath.Join(dir, tuiFile)
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &TUI{path: path}, nil
+	}
+	if err != nil {
+		return &TUI{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	cfg := &TUI{}
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return &TUI{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	cfg.path = path
+	return cfg, nil
+}
+
+// Save writes the TUI state back to the file it was loaded from. State that was not loaded
+// from a file is kept in memory only.
+func (c *TUI) Save() error {
+	if c.path == "" {
+		return nil
+	}
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode TUI config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, content, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.path, err)
+	}
+	return nil
+}
+
+// IsBookmarked reports whether the CRD with the given full name is bookmarked.
+func (c *TUI) IsBookmarked(crdName string) bool {
+	return slices.Contains(c.Bookmarks, crdName)
+}
+
+// ToggleBookmark adds or removes the bookmark of a CRD and reports whether it is now bookmarked.
+func (c *TUI) ToggleBookmark(crdName string) bool {
+	if i := slices.Index(c.Bookmarks, crdName); i >= 0 {
+		c.Bookmarks = slices.Delete(c.Bookmarks, i, i+1)
+		return false
+	}
+	c.Bookmarks = append(c.Bookmarks, crdName)
+	slices.Sort(c.Bookmarks)
+	return true
+}
+
+// AddRecent moves the item to the top of the recent list, dropping the oldest entries beyond MaxRecent.
+func (c *TUI) AddRecent(item RecentItem) {
+	c.Recent = slices.DeleteFunc(c.Recent, func(r RecentItem) bool { return r == item })
+	c.Recent = slices.Insert(c.Recent, 0, item)
+	if len(c.Recent) > MaxRecent {
+		c.Recent = c.Recent[:MaxRecent]
+	}
+}
+
+// RecentFor returns the recently viewed items of a cluster, newest first.
+func (c *TUI) RecentFor(cluster string) []RecentItem {
+	var items []RecentItem
+	for _, item := range c.Recent {
+		if item.Cluster == cluster {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -18,52 +18,123 @@ package generator
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	htmltemplate "html/template"
+	"slices"
 	"sort"
+	"strings"
 	"text/template"
+	"unicode/utf8"
+
+	"github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	"github.com/pehlicd/crd-wizard/internal/models"
 )
 
+// Options customizes the generated documentation.
+type Options struct {
+	// HTMLTitle is prepended to the page title and shown above the document header.
+	HTMLTitle string
+	// HTMLLogo is the URL of a logo image shown in the document header.
+	HTMLLogo string
+	// HTMLCSS is appended to the built-in stylesheet, so it can override any of its rules.
+	HTMLCSS string
+	// MaxDescLength truncates descriptions longer than this many characters in HTML, with a
+	// toggle to show the rest, and in Markdown, with an ellipsis. Zero keeps them whole.
+	// JSON output always holds the full descriptions.
+	MaxDescLength int
+	// StructuredMetadata embeds a JSON-LD block with the group, kind, versions and scope of
+	// the CRD in the head of HTML documentation, so hosted pages are machine-discoverable.
+	StructuredMetadata bool
+	// DescribeFields returns descriptions for the fields of the schema that have none, keyed
+	// by paths such as spec.containers[].name. They fill in empty descriptions only and are
+	// marked as AI-generated. Nil leaves descriptions as the CRD has them.
+	DescribeFields func(schema apiextensionsv1.JSONSchemaProps) map[string]string
+}
+
 // Generator handles the generation of documentation from CRDs.
-type Generator struct{}
+type Generator struct {
+	opts Options
+}
 
 // NewGenerator creates a new Generator.
-func NewGenerator() *Generator {
-	return &Generator{}
+func NewGenerator(opts Options) *Generator {
+	return &Generator{opts: opts}
 }
 
 // DocData represents the data structure passed to the templates.
 type DocData struct {
-	APIVersion   string
-	Kind         string
-	ResourceKind string
-	Metadata     DocMetadata
-	Spec         DocSchema
+	APIVersion   string      `json:"apiVersion"`
+	Kind         string      `json:"kind"`
+	ResourceKind string      `json:"resourceKind"`
+	Metadata     DocMetadata `json:"metadata"`
+	// NoSchema is set for CRDs without an OpenAPI v3 schema, such as legacy v1beta1 CRDs,
+	// which are documented by their metadata only.
+	NoSchema bool `json:"noSchema,omitempty"`
+	// Description is the description of the resource, from the root of the schema.
+	Description string `json:"description,omitempty"`
+	// Required lists the top-level fields the root of the schema requires, e.g. spec.
+	Required []string `json:"required,omitempty"`
+	// MetadataConstraints documents what the CRD requires of metadata, such as a name
+	// pattern. It is nil when the schema does not constrain metadata.
+	MetadataConstraints *DocSchema `json:"metadataConstraints,omitempty"`
+	// Spec documents the desired state, the spec field of the resource.
+	Spec DocSchema `json:"spec"`
+	// Status documents the observed state, nil when the schema has no status field.
+	Status   *DocSchema  `json:"status,omitempty"`
+	Branding DocBranding `json:"-"`
+	// Examples holds YAML manifests shown in the Examples section.
+	Examples []string `json:"examples,omitempty"`
+	// ExamplesFromSchema is set when Examples were generated from the schema
+	// because no live instances exist.
+	ExamplesFromSchema bool `json:"examplesFromSchema,omitempty"`
+}
+
+// DocBranding holds the HTML branding options.
+type DocBranding struct {
+	Title   string
+	LogoURL string
+	// CSS is trusted, it comes from the user generating the documentation and not from a CRD.
+	CSS htmltemplate.CSS
 }
 
 type DocMetadata struct {
-	Name     string
-	Group    string
-	Scope    string
-	Versions []string
+	Name                 string   `json:"name"`
+	Group                string   `json:"group"`
+	Scope                string   `json:"scope"`
+	Versions             []string `json:"versions"`
+	ShortNames           []string `json:"shortNames,omitempty"`
+	Categories           []string `json:"categories,omitempty"`
+	HasConversionWebhook bool     `json:"hasConversionWebhook"`
 }
 
 type DocSchema struct {
-	Description string
-	Fields      []DocField
+	Description string `json:"description,omitempty"`
+	// Required is set when the root of the schema requires this section.
+	Required bool       `json:"required,omitempty"`
+	Fields   []DocField `json:"fields"`
 }
 
 type DocField struct {
-	Name        string
-	Type        string
-	Description string
-	Required    bool
-	Default     string
-	Enum        []string
-	Fields      []DocField // Nested fields
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required"`
+	Default     string   `json:"default,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	Pattern     string   `json:"pattern,omitempty"`
+	MaxLength   *int64   `json:"maxLength,omitempty"`
+	// AIGenerated is set when Description was written by AI because the CRD has none.
+	AIGenerated bool       `json:"aiGenerated,omitempty"`
+	Fields      []DocField `json:"fields,omitempty"` // Nested fields
 }
 
 // Generate generates documentation for the given CRD in the specified format.
@@ -73,29 +144,221 @@ func (g *Generator) Generate(crd models.APICRD, format string) ([]byte, error) {
 		return nil, err
 	}
 
-	var tmplStr string
+	return g.Render(data, format)
+}
+
+// GenerateAll generates documentation for several CRDs as a single document.
+// JSON output is always an array, even for a single CRD, so its shape does not depend
+// on the input. HTML is a single page with a section per CRD and Markdown documents
+// are concatenated.
+func (g *Generator) GenerateAll(crds []models.APICRD, format string) ([]byte, error) {
+	if format == "json" {
+		docs := make([]DocData, 0, len(crds))
+		for _, crd := range crds {
+			data, err := g.Parse(crd)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", crd.Metadata.Name, err)
+			}
+			docs = append(docs, data)
+		}
+		return marshalJSON(docs)
+	}
+
+	if len(crds) == 1 {
+		return g.Generate(crds[0], format)
+	}
+
+	if format == "html" {
+		docs := make([]DocData, 0, len(crds))
+		for _, crd := range crds {
+			data, err := g.Parse(crd)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", crd.Metadata.Name, err)
+			}
+			docs = append(docs, data)
+		}
+		return g.renderHTML(docs)
+	}
+
+	var buf bytes.Buffer
+	for i, crd := range crds {
+		content, err := g.Generate(crd, format)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", crd.Metadata.Name, err)
+		}
+		if i > 0 {
+			buf.WriteString("\n---\n")
+		}
+		buf.Write(content)
+	}
+	return buf.Bytes(), nil
+}
+
+// Render renders already parsed documentation data in the specified format.
+func (g *Generator) Render(data DocData, format string) ([]byte, error) {
+	if format == "json" {
+		return marshalJSON(data)
+	}
+
 	switch format {
 	case "markdown", "md":
-		tmplStr = MarkdownTemplate
+		return executeTemplate("doc", MarkdownTemplate, format, g.docFuncs(), data)
 	case "html":
-		tmplStr = HTMLTemplate
-	default:
-		return nil, fmt.Errorf("unsupported format: %s", format)
+		return g.renderHTML([]DocData{data})
 	}
+	return nil, fmt.Errorf("unsupported format: %s", format)
+}
 
-	tmpl, err := template.New("doc").Parse(tmplStr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse template: %w", err)
+// htmlPage represents the data structure passed to the HTML template, one page documenting
+// one or more CRDs.
+type htmlPage struct {
+	Docs     []DocData
+	Branding DocBranding
+}
+
+// renderHTML renders docs as a single HTML page, with a section per CRD when there are several.
+func (g *Generator) renderHTML(docs []DocData) ([]byte, error) {
+	page := htmlPage{Docs: docs}
+	if len(docs) > 0 {
+		page.Branding = docs[0].Branding
+	}
+	// The brand heads the page once, not every section of it.
+	for i := 1; i < len(docs); i++ {
+		docs[i].Branding = DocBranding{}
 	}
+	return executeTemplate("doc", HTMLTemplate, "html", g.docFuncs(), page)
+}
+
+// docFuncs returns the template functions of the documentation templates.
+func (g *Generator) docFuncs() map[string]any {
+	return map[string]any{
+		"highlightYAML":  highlightYAML,
+		"truncateDesc":   g.truncateDesc,
+		"descTruncated":  g.descTruncated,
+		"markdown":       markdownHTML,
+		"structuredData": g.structuredData,
+	}
+}
 
+// executeTemplate renders data with tmplStr. HTML is rendered with html/template, so that
+// strings taken from CRDs are escaped for the context they appear in and cannot inject
+// markup or scripts. Markdown is rendered with text/template, which keeps them verbatim.
+func executeTemplate(name, tmplStr, format string, funcs map[string]any, data any) ([]byte, error) {
 	var buf bytes.Buffer
+	if format == "html" {
+		tmpl, err := htmltemplate.New(name).Funcs(funcs).Parse(tmplStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template: %w", err)
+		}
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to execute template: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs).Parse(tmplStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
 	if err := tmpl.Execute(&buf, data); err != nil {
 		return nil, fmt.Errorf("failed to execute template: %w", err)
 	}
+	return buf.Bytes(), nil
+}
 
+// marshalJSON renders v as indented JSON for the json output format.
+func marshalJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
 	return buf.Bytes(), nil
 }
 
+// structuredData returns the schema.org description of the documented CRD, or nil when
+// Options.StructuredMetadata is not set. html/template encodes it as JSON in the page.
+func (g *Generator) structuredData(data DocData) map[string]any {
+	if !g.opts.StructuredMetadata {
+		return nil
+	}
+	properties := []map[string]any{
+		{"@type": "PropertyValue", "name": "group", "value": data.Metadata.Group},
+		{"@type": "PropertyValue", "name": "kind", "value": data.ResourceKind},
+		{"@type": "PropertyValue", "name": "scope", "value": data.Metadata.Scope},
+		{"@type": "PropertyValue", "name": "versions", "value": data.Metadata.Versions},
+	}
+	return map[string]any{
+		"@context":    "https://schema.org",
+		"@type":       "TechArticle",
+		"headline":    fmt.Sprintf("%s (%s) Documentation", data.ResourceKind, data.Metadata.Name),
+		"description": data.Description,
+		"keywords":    append([]string{"CustomResourceDefinition", data.Metadata.Group, data.ResourceKind}, data.Metadata.Versions...),
+		"about": map[string]any{
+			"@type":              "Thing",
+			"name":               data.ResourceKind,
+			"identifier":         data.Metadata.Name,
+			"additionalProperty": properties,
+		},
+	}
+}
+
+// descTruncated reports whether truncateDesc shortens the description.
+func (g *Generator) descTruncated(desc string) bool {
+	return g.opts.MaxDescLength > 0 && utf8.RuneCountInString(desc) > g.opts.MaxDescLength
+}
+
+// truncateDesc shortens a description to Options.MaxDescLength characters, cutting at the
+// last word boundary when there is one, and marks the cut with an ellipsis.
+func (g *Generator) truncateDesc(desc string) string {
+	if !g.descTruncated(desc) {
+		return desc
+	}
+	short := string([]rune(desc)[:g.opts.MaxDescLength])
+	if i := strings.LastIndexAny(short, " \n\t"); i > 0 {
+		short = short[:i]
+	}
+	return strings.TrimRight(short, " \n\t.,;:") + "…"
+}
+
+// markdownRenderer renders descriptions, which CRD authors often write in GitHub flavoured Markdown.
+var markdownRenderer = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// descriptionPolicy strips everything from rendered descriptions that could run scripts or
+// break the page, as descriptions come from arbitrary CRDs.
+var descriptionPolicy = bluemonday.UGCPolicy()
+
+// markdownHTML renders a description written in Markdown as sanitized HTML, falling back to
+// escaped plain text. A description of a single paragraph is not wrapped in <p>, so it
+// renders inline like plain text does.
+func markdownHTML(desc string) htmltemplate.HTML {
+	var buf bytes.Buffer
+	if err := markdownRenderer.Convert([]byte(desc), &buf); err != nil {
+		return htmltemplate.HTML(template.HTMLEscapeString(desc)) //nolint:gosec // escaped above
+	}
+	out := strings.TrimSpace(descriptionPolicy.Sanitize(buf.String()))
+	if inner, ok := strings.CutPrefix(out, "<p>"); ok && strings.HasSuffix(inner, "</p>") && strings.Count(out, "<p>") == 1 {
+		out = strings.TrimSuffix(inner, "</p>")
+	}
+	return htmltemplate.HTML(out) //nolint:gosec // sanitized above
+}
+
+// highlightYAML renders YAML as syntax-highlighted HTML, falling back to escaped plain text.
+func highlightYAML(content string) htmltemplate.HTML {
+	escaped := htmltemplate.HTML("<pre>" + template.HTMLEscapeString(content) + "</pre>") //nolint:gosec // escaped
+	it, err := lexers.Get("yaml").Tokenise(nil, content)
+	if err != nil {
+		return escaped
+	}
+	var buf bytes.Buffer
+	if err := html.New(html.WithClasses(false)).Format(&buf, styles.Get("github"), it); err != nil {
+		return escaped
+	}
+	return htmltemplate.HTML(buf.String()) //nolint:gosec // chroma escapes the tokens it formats
+}
+
 // Parse extracts documentation data from the CRD.
 func (g *Generator) Parse(crd models.APICRD) (DocData, error) {
 	// Find the storage version or the first version to get the schema
@@ -111,31 +374,138 @@ func (g *Generator) Parse(crd models.APICRD) (DocData, error) {
 		}
 	}
 
-	// Fallback if storage version doesn't have schema (unlikely but possible in some valid CRDs that use global schema in older versions, though v1 requires per-version)
-	if schema == nil && len(crd.Spec.Versions) > 0 {
-		if crd.Spec.Versions[0].Schema != nil && crd.Spec.Versions[0].Schema.OpenAPIV3Schema != nil {
-			schema = crd.Spec.Versions[0].Schema.OpenAPIV3Schema
-		}
-	}
-
+	// Fallback if the storage version doesn't have a schema. v1 requires one per version, but
+	// CRDs converted from v1beta1 only have the schemas their authors declared, which may be
+	// on other versions than the storage version or, with a global spec.validation, on all of them.
 	if schema == nil {
-		return DocData{}, fmt.Errorf("could not find OpenAPI V3 schema in CRD")
+		for _, v := range crd.Spec.Versions {
+			if v.Schema != nil && v.Schema.OpenAPIV3Schema != nil {
+				schema = v.Schema.OpenAPIV3Schema
+				break
+			}
+		}
 	}
 
-	docSchema := g.parseSchema(*schema)
-
-	return DocData{
+	data := DocData{
 		APIVersion:   crd.APIVersion,
 		Kind:         crd.Kind,
 		ResourceKind: crd.Spec.Names.Kind,
 		Metadata: DocMetadata{
-			Name:     crd.Metadata.Name,
-			Group:    crd.Spec.Group,
-			Scope:    string(crd.Spec.Scope),
-			Versions: versions,
+			Name:                 crd.Metadata.Name,
+			Group:                crd.Spec.Group,
+			Scope:                string(crd.Spec.Scope),
+			Versions:             versions,
+			ShortNames:           crd.ShortNames,
+			Categories:           crd.Categories,
+			HasConversionWebhook: crd.HasConversionWebhook,
 		},
-		Spec: docSchema,
-	}, nil
+		Branding: DocBranding{
+			Title:   g.opts.HTMLTitle,
+			LogoURL: g.opts.HTMLLogo,
+			CSS:     htmltemplate.CSS(g.opts.HTMLCSS), //nolint:gosec // given by the user running the tool
+		},
+	}
+
+	// Without a schema there are no fields to document, but the metadata is still worth it.
+	if schema == nil {
+		data.NoSchema = true
+		return data, nil
+	}
+
+	sections := g.splitSchema(*schema)
+	if g.opts.DescribeFields != nil {
+		if descriptions := g.opts.DescribeFields(*schema); len(descriptions) > 0 {
+			sections.fillDescriptions(descriptions)
+		}
+	}
+
+	data.Description = schema.Description
+	data.Required = sections.required
+	data.MetadataConstraints = sections.metadata
+	data.Spec = sections.spec
+	data.Status = sections.status
+	return data, nil
+}
+
+// rootFields are the fields every resource has, which are not worth documenting per CRD.
+var rootFields = []string{"apiVersion", "kind", "metadata"}
+
+// docSections are the top-level parts of a resource documented separately.
+type docSections struct {
+	required []string
+	metadata *DocSchema
+	spec     DocSchema
+	status   *DocSchema
+	// specPath is the path of the spec fields, empty when they are kept at the root.
+	specPath string
+}
+
+// fillDescriptions sets the descriptions of the fields that have none from descriptions,
+// which is keyed by field path.
+func (s *docSections) fillDescriptions(descriptions map[string]string) {
+	if s.metadata != nil {
+		fillDescriptions(s.metadata.Fields, "metadata", descriptions)
+	}
+	fillDescriptions(s.spec.Fields, s.specPath, descriptions)
+	if s.status != nil {
+		fillDescriptions(s.status.Fields, "status", descriptions)
+	}
+}
+
+func fillDescriptions(fields []DocField, prefix string, descriptions map[string]string) {
+	for i := range fields {
+		field := &fields[i]
+		path := field.Name
+		if prefix != "" {
+			path = prefix + "." + field.Name
+		}
+		if desc, ok := descriptions[path]; ok && field.Description == "" {
+			field.Description = desc
+			field.AIGenerated = true
+		}
+		// Fields of array items are addressed through the array, e.g. containers[].name.
+		if strings.HasPrefix(field.Type, "[]") {
+			path += "[]"
+		}
+		fillDescriptions(field.Fields, path, descriptions)
+	}
+}
+
+// splitSchema documents the metadata, spec and status fields of the root schema separately,
+// marking the ones the root requires. A few CRDs keep their fields at the root instead of
+// in spec; those are documented as the spec.
+func (g *Generator) splitSchema(root apiextensionsv1.JSONSchemaProps) docSections {
+	sections := docSections{required: slices.Sorted(slices.Values(root.Required))}
+	section := func(name string) (*DocSchema, bool) {
+		s, ok := root.Properties[name]
+		if !ok {
+			return nil, false
+		}
+		parsed := g.parseSchema(s)
+		parsed.Required = slices.Contains(root.Required, name)
+		return &parsed, true
+	}
+
+	// metadata is only worth a section when the CRD constrains it beyond the usual object metadata.
+	if metadata, ok := section("metadata"); ok && len(metadata.Fields) > 0 {
+		sections.metadata = metadata
+	}
+	sections.status, _ = section("status")
+
+	if spec, ok := section("spec"); ok {
+		sections.spec = *spec
+		sections.specPath = "spec"
+		return sections
+	}
+
+	properties := make(map[string]apiextensionsv1.JSONSchemaProps, len(root.Properties))
+	for name, prop := range root.Properties {
+		if name != "status" && !slices.Contains(rootFields, name) {
+			properties[name] = prop
+		}
+	}
+	sections.spec = DocSchema{Fields: g.parseFields(properties, root.Required)}
+	return sections
 }
 
 func (g *Generator) parseSchema(schema apiextensionsv1.JSONSchemaProps) DocSchema {
@@ -183,6 +553,9 @@ func (g *Generator) parseFields(properties map[string]apiextensionsv1.JSONSchema
 			}
 		}
 
+		field.Pattern = prop.Pattern
+		field.MaxLength = prop.MaxLength
+
 		// Handle arrays
 		if prop.Type == "array" && prop.Items != nil {
 			if prop.Items.Schema != nil {
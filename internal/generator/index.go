@@ -0,0 +1,95 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generator
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"sort"
+)
+
+// IndexEntry is a single exported CRD document listed in the index.
+type IndexEntry struct {
+	Name  string `json:"name"`
+	Kind  string `json:"kind"`
+	Group string `json:"group"`
+	// File is the path of the document relative to the index.
+	File string `json:"file"`
+	// Instances is the number of custom resources of the CRD, nil when they were not counted.
+	Instances *int `json:"instances,omitempty"`
+}
+
+// IndexGroup lists the entries sharing an API group.
+type IndexGroup struct {
+	Name    string       `json:"name"`
+	Entries []IndexEntry `json:"entries"`
+}
+
+// IndexData represents the data structure passed to the index templates.
+type IndexData struct {
+	Groups   []IndexGroup `json:"groups"`
+	Total    int          `json:"total"`
+	Branding DocBranding  `json:"-"`
+}
+
+// Index generates a page linking to every exported document, grouped by API group.
+func (g *Generator) Index(entries []IndexEntry, format string) ([]byte, error) {
+	if format == "json" {
+		return marshalJSON(g.indexData(entries))
+	}
+
+	var tmplStr string
+	switch format {
+	case "markdown", "md":
+		tmplStr = IndexMarkdownTemplate
+	case "html":
+		tmplStr = IndexHTMLTemplate
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+
+	return executeTemplate("index", tmplStr, format, nil, g.indexData(entries))
+}
+
+func (g *Generator) indexData(entries []IndexEntry) IndexData {
+	sorted := make([]IndexEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Group != sorted[j].Group {
+			return sorted[i].Group < sorted[j].Group
+		}
+		return sorted[i].Kind < sorted[j].Kind
+	})
+
+	var groups []IndexGroup
+	for _, entry := range sorted {
+		if len(groups) == 0 || groups[len(groups)-1].Name != entry.Group {
+			groups = append(groups, IndexGroup{Name: entry.Group})
+		}
+		groups[len(groups)-1].Entries = append(groups[len(groups)-1].Entries, entry)
+	}
+
+	return IndexData{
+		Groups: groups,
+		Total:  len(sorted),
+		Branding: DocBranding{
+			Title:   g.opts.HTMLTitle,
+			LogoURL: g.opts.HTMLLogo,
+			CSS:     htmltemplate.CSS(g.opts.HTMLCSS), //nolint:gosec // given by the user running the tool
+		},
+	}
+}
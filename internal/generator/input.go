@@ -0,0 +1,109 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/pehlicd/crd-wizard/internal/models"
+)
+
+// ErrNoCRDs is returned by ParseCRDs when the input holds no CustomResourceDefinition.
+var ErrNoCRDs = errors.New("no CustomResourceDefinition found")
+
+// ParseCRDs decodes every CRD in a YAML or JSON stream that may hold several documents,
+// such as an operator install bundle. Documents of any other kind, or that cannot be
+// decoded as a CRD, are skipped; a reason for each is returned so callers can warn
+// about them.
+func ParseCRDs(content []byte) ([]apiextensionsv1.CustomResourceDefinition, []string, error) {
+	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+
+	var crds []apiextensionsv1.CustomResourceDefinition
+	var skipped []string
+	for doc := 1; ; doc++ {
+		raw, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read document %d: %w", doc, err)
+		}
+
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+
+		// Only look at the kind first, other objects may not even decode into a CRD.
+		var object metav1.PartialObjectMetadata
+		if err := yaml.Unmarshal(raw, &object); err != nil {
+			skipped = append(skipped, fmt.Sprintf("document %d: %v", doc, err))
+			continue
+		}
+		if object.Kind == "" && object.APIVersion == "" && object.Name == "" {
+			// Only comments, nothing to document.
+			continue
+		}
+		if object.Kind != "CustomResourceDefinition" {
+			skipped = append(skipped, fmt.Sprintf("document %d: %s %q is not a CustomResourceDefinition", doc, object.Kind, object.Name))
+			continue
+		}
+
+		crd, err := decodeCRD(raw, object.APIVersion)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("document %d: CustomResourceDefinition %q: %v", doc, object.Name, err))
+			continue
+		}
+		crds = append(crds, crd)
+	}
+
+	if len(crds) == 0 {
+		if len(skipped) == 0 {
+			return nil, nil, fmt.Errorf("%w: the input is empty", ErrNoCRDs)
+		}
+		return nil, skipped, fmt.Errorf("%w: skipped %d documents, make sure the input contains objects of kind CustomResourceDefinition", ErrNoCRDs, len(skipped))
+	}
+
+	return crds, skipped, nil
+}
+
+// decodeCRD decodes a CRD document. Documents of the deprecated apiextensions.k8s.io/v1beta1
+// API are converted into their v1 form, which moves the global spec.validation schema into
+// every version.
+func decodeCRD(raw []byte, apiVersion string) (apiextensionsv1.CustomResourceDefinition, error) {
+	if apiVersion == apiextensionsv1beta1.SchemeGroupVersion.String() {
+		var crd apiextensionsv1beta1.CustomResourceDefinition
+		if err := yaml.Unmarshal(raw, &crd); err != nil {
+			return apiextensionsv1.CustomResourceDefinition{}, err
+		}
+		return models.FromV1beta1CRD(crd)
+	}
+
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := yaml.Unmarshal(raw, &crd); err != nil {
+		return apiextensionsv1.CustomResourceDefinition{}, err
+	}
+	return crd, nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generator
+
+import (
+	"time"
+
+	"github.com/pehlicd/crd-wizard/internal/models"
+)
+
+// ReportData represents the data structure passed to the cluster report template.
+type ReportData struct {
+	IndexData
+	Cluster models.ClusterInfo
+	// Instances is the number of custom resources of all CRDs in the report.
+	Instances int
+	// Skipped lists the CRDs that could not be documented, with the reason why.
+	Skipped   []string
+	Generated string
+}
+
+// Report generates the landing page of a cluster report: the cluster, its CRDs grouped by API
+// group with their instance counts, and links to their documents. Reports are always HTML.
+func (g *Generator) Report(cluster models.ClusterInfo, entries []IndexEntry, skipped []string) ([]byte, error) {
+	data := ReportData{
+		IndexData: g.indexData(entries),
+		Cluster:   cluster,
+		Skipped:   skipped,
+		Generated: time.Now().UTC().Format(time.RFC1123),
+	}
+	for _, entry := range entries {
+		if entry.Instances != nil {
+			data.Instances += *entry.Instances
+		}
+	}
+	funcs := map[string]any{
+		"deref": func(n *int) int { return *n },
+	}
+	return executeTemplate("report", ReportHTMLTemplate, "html", funcs, data)
+}
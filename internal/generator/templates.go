@@ -24,14 +24,53 @@ const MarkdownTemplate = `
 | **Group** | {{ .Metadata.Group }} |
 | **Scope** | {{ .Metadata.Scope }} |
 | **Versions** | {{ range .Metadata.Versions }}{{ . }} {{ end }} |
-
+{{- if .Metadata.ShortNames }}
+| **Short Names** | {{ range .Metadata.ShortNames }}{{ . }} {{ end }} |
+{{- end }}
+{{- if .Metadata.Categories }}
+| **Categories** | {{ range .Metadata.Categories }}{{ . }} {{ end }} |
+{{- end }}
+{{ if .Metadata.HasConversionWebhook }}
+> **Note:** This CRD uses a conversion webhook. Objects are transformed by an external webhook when read or written in a version other than the storage version.
+{{ end }}
+{{- if .NoSchema }}
+> **Note:** This CRD defines no OpenAPI v3 schema, so its fields are neither validated by the API server nor documented here.
+{{ else }}
 ## Description
 
-{{ .Spec.Description }}
-
-## Specification
-
+{{ truncateDesc .Description }}
+{{ if .Required }}
+**Required top-level fields:** {{ range .Required }}<code>{{ . }}</code> {{ end }}
+{{ end }}
+{{- with .MetadataConstraints }}
+## Metadata{{ if .Required }} *(required)*{{ end }}
+{{ if .Description }}
+{{ truncateDesc .Description }}
+{{ end }}
+{{ template "fields" .Fields }}
+{{ end }}
+## Spec{{ if .Spec.Required }} *(required)*{{ end }}
+{{ if .Spec.Description }}
+{{ truncateDesc .Spec.Description }}
+{{ end }}
 {{ template "fields" .Spec.Fields }}
+{{ if .Status }}
+## Status{{ if .Status.Required }} *(required)*{{ end }}
+{{ if .Status.Description }}
+{{ truncateDesc .Status.Description }}
+{{ end }}
+{{ template "fields" .Status.Fields }}
+{{ end }}{{ end }}{{ if .Examples }}
+## Examples
+{{ if .ExamplesFromSchema }}
+No instances exist in the cluster, so this example was generated from the schema.
+{{ end }}
+{{- range .Examples }}
+` + "```yaml" + `
+{{ . }}
+` + "```" + `
+{{ end }}
+{{- end }}
 
 {{- define "fields" -}}
 {{- range . -}}
@@ -43,14 +82,16 @@ const MarkdownTemplate = `
 </summary>
 
 {{ if .Description }}
-> {{ .Description }}
+> {{ truncateDesc .Description }}{{ if .AIGenerated }} *(AI-generated)*{{ end }}
 {{ end }}
 
-{{ if or .Default .Enum }}
+{{ if or .Default .Enum .Pattern .MaxLength }}
 | Attribute | Value |
 | :--- | :--- |
 {{ if .Default }}| **Default** | <code>{{ .Default }}</code> |{{ end }}
 {{ if .Enum }}| **Enum** | {{ range .Enum }}<code>{{ . }}</code> {{ end }} |{{ end }}
+{{ if .Pattern }}| **Pattern** | <code>{{ .Pattern }}</code> |{{ end }}
+{{ if .MaxLength }}| **Max Length** | {{ .MaxLength }} |{{ end }}
 {{ end }}
 
 {{ if .Fields }}
@@ -71,7 +112,8 @@ const HTMLTemplate = `
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>{{ .ResourceKind }} ({{ .Metadata.Name }}) Documentation</title>
+    <title>{{ if .Branding.Title }}{{ .Branding.Title }} - {{ end }}{{ if eq (len .Docs) 1 }}{{ with index .Docs 0 }}{{ .ResourceKind }} ({{ .Metadata.Name }}) Documentation{{ end }}{{ else }}Custom Resource Definitions{{ end }}</title>
+    {{ range .Docs }}{{ with structuredData . }}<script type="application/ld+json">{{ . }}</script>{{ end }}{{ end }}
     <style>
         :root {
             --bg-body: #f8fafc;
@@ -165,6 +207,16 @@ const HTMLTemplate = `
             font-size: 1.1rem;
         }
 
+        .callout {
+            background: #fffbeb;
+            border: 1px solid #fde68a;
+            color: #92400e;
+            border-radius: 0.5rem;
+            padding: 0.75rem 1rem;
+            margin-bottom: 1.5rem;
+            font-size: 0.95rem;
+        }
+
         /* Controls */
         .controls {
             position: sticky;
@@ -310,12 +362,35 @@ const HTMLTemplate = `
             border: 1px solid #fee2e2;
         }
 
+        .badge-ai {
+            font-size: 0.7rem;
+            color: #7c3aed;
+            background: #f5f3ff;
+            padding: 1px 6px;
+            border-radius: 99px;
+            font-weight: 600;
+            border: 1px solid #ede9fe;
+        }
+
         .field-desc {
             font-size: 0.9rem;
             color: var(--text-muted);
             margin: 0.25rem 0;
         }
 
+        /* Markdown rendered in descriptions */
+        .description p, .field-desc p { margin: 0.25rem 0; }
+        .description ul, .description ol, .field-desc ul, .field-desc ol { margin: 0.25rem 0; padding-left: 1.5rem; }
+        .description code, .field-desc code { background: var(--primary-bg); padding: 0 4px; border-radius: 4px; font-size: 0.85em; }
+        .description a, .field-desc a { color: var(--primary); }
+
+        /* Descriptions shortened by --max-desc-length */
+        .more summary { cursor: pointer; list-style: none; }
+        .more summary::-webkit-details-marker { display: none; }
+        .more summary::after { content: " show more"; color: var(--primary); font-weight: 500; }
+        .more[open] summary .short { display: none; }
+        .more[open] summary::after { content: "show less"; }
+
         .field-meta {
             font-size: 0.85rem;
             color: var(--text-muted);
@@ -346,44 +421,51 @@ const HTMLTemplate = `
         [data-theme="dark"] button { background: #1e293b; color: #e2e8f0; border-color: #475569; }
         [data-theme="dark"] button:hover { background: var(--primary-bg); color: var(--primary); border-color: var(--primary); }
         [data-theme="dark"] #search-input { background: #1e293b; color: white; border-color: #475569; }
+        [data-theme="dark"] .callout { background: rgba(251, 191, 36, 0.1); border-color: #92400e; color: #fbbf24; }
+
+        .brand { display: flex; align-items: center; gap: 0.75rem; margin-bottom: 1rem; color: var(--text-muted); font-weight: 600; }
+        .brand img { max-height: 40px; }
+
+        .section-title { margin: 2rem 0 0.5rem; }
+        .section-desc { color: var(--text-muted); margin-bottom: 1rem; }
+        .section-title .badge-req { font-size: 0.6em; vertical-align: middle; }
+        .required-fields { margin-top: 0.75rem; color: var(--text-muted); }
+        .required-fields code { background: var(--primary-bg); padding: 0 4px; border-radius: 4px; }
+
+        .examples { margin-top: 2rem; }
+        .examples-note { color: var(--text-muted); }
+        .example pre { padding: 1rem; border-radius: 0.5rem; border: 1px solid var(--border-color); overflow-x: auto; font-size: 0.85rem; }
+
+        .doc-toc { display: flex; flex-wrap: wrap; gap: 0.5rem 1rem; margin-bottom: 2rem; }
+        .doc-toc a { color: var(--primary); text-decoration: none; font-weight: 500; }
+        .crd-doc + .crd-doc { margin-top: 4rem; padding-top: 2rem; border-top: 2px solid var(--border-color); }
+{{ if .Branding.CSS }}
+        /* Custom CSS */
+{{ .Branding.CSS }}
+{{ end }}
     </style>
 </head>
 <body>
 
 <div class="container">
-    <div class="doc-header">
-        <h1 class="doc-title">{{ .ResourceKind }} <span style="font-size: 0.6em; color: var(--text-muted); font-weight: normal;">{{ .Metadata.Name }}</span></h1>
-        <div class="meta-grid">
-            <div class="meta-item">
-                <label>Group</label>
-                <span>{{ .Metadata.Group }}</span>
-            </div>
-            <div class="meta-item">
-                <label>Scope</label>
-                <span>{{ .Metadata.Scope }}</span>
-            </div>
-            <div class="meta-item">
-                <label>Versions</label>
-                <span>{{ range .Metadata.Versions }}{{ . }} {{ end }}</span>
-            </div>
-        </div>
-        <div class="description">
-            {{ .Spec.Description }}
-        </div>
-    </div>
-
-    <div class="controls">
-        <div class="btn-group">
-            <button onclick="toggleAll(true)">Expand All</button>
-            <button onclick="toggleAll(false)">Collapse All</button>
-            <button onclick="toggleTheme()">Theme</button>
-        </div>
-        <input type="text" id="search-input" placeholder="Search fields..." onkeyup="filterFields()">
-    </div>
-
-    <div class="spec-container">
-        {{ template "fields" .Spec.Fields }}
-    </div>
+    {{ if eq (len .Docs) 1 }}
+    {{ with index .Docs 0 }}
+{{ template "header" . }}
+{{ template "controls" }}
+{{ template "body" . }}
+    {{ end }}
+    {{ else }}
+{{ template "controls" }}
+    <nav class="doc-toc">
+        {{ range .Docs }}<a href="#{{ .Metadata.Name }}">{{ .ResourceKind }}</a> {{ end }}
+    </nav>
+    {{ range .Docs }}
+    <section class="crd-doc" id="{{ .Metadata.Name }}">
+{{ template "header" . }}
+{{ template "body" . }}
+    </section>
+    {{ end }}
+    {{ end }}
 </div>
 
 <script>
@@ -495,13 +577,15 @@ const HTMLTemplate = `
                     </div>
                     
                     {{ if .Description }}
-                    <div class="field-desc">{{ .Description }}</div>
+                    <div class="field-desc">{{ if .AIGenerated }}<span class="badge-ai" title="The CRD has no description for this field, this one was written by AI">AI-generated</span> {{ end }}{{ template "desc" .Description }}</div>
                     {{ end }}
 
-                    {{ if or .Default .Enum }}
+                    {{ if or .Default .Enum .Pattern .MaxLength }}
                     <div class="field-meta">
                         {{ if .Default }}<span>Default: {{ .Default }}</span>{{ end }}
                         {{ if .Enum }}<span>Enum: [ {{ range .Enum }}{{ . }} {{ end }}]</span>{{ end }}
+                        {{ if .Pattern }}<span>Pattern: <code>{{ .Pattern }}</code></span>{{ end }}
+                        {{ if .MaxLength }}<span>Max length: {{ .MaxLength }}</span>{{ end }}
                     </div>
                     {{ end }}
                 </div>
@@ -516,4 +600,304 @@ const HTMLTemplate = `
     </div>
     {{ end }}
 {{ end }}
+
+{{ define "desc" -}}
+{{ if descTruncated . -}}
+<details class="more"><summary><span class="short">{{ truncateDesc . }}</span></summary>{{ markdown . }}</details>
+{{- else -}}
+{{ markdown . }}
+{{- end }}
+{{- end }}
+
+{{ define "header" }}
+    <div class="doc-header">
+        {{ if or .Branding.LogoURL .Branding.Title }}
+        <div class="brand">
+            {{ if .Branding.LogoURL }}<img src="{{ .Branding.LogoURL }}" alt="{{ .Branding.Title }}">{{ end }}
+            {{ if .Branding.Title }}<span>{{ .Branding.Title }}</span>{{ end }}
+        </div>
+        {{ end }}
+        <h1 class="doc-title">{{ .ResourceKind }} <span style="font-size: 0.6em; color: var(--text-muted); font-weight: normal;">{{ .Metadata.Name }}</span></h1>
+        <div class="meta-grid">
+            <div class="meta-item">
+                <label>Group</label>
+                <span>{{ .Metadata.Group }}</span>
+            </div>
+            <div class="meta-item">
+                <label>Scope</label>
+                <span>{{ .Metadata.Scope }}</span>
+            </div>
+            <div class="meta-item">
+                <label>Versions</label>
+                <span>{{ range .Metadata.Versions }}{{ . }} {{ end }}</span>
+            </div>
+            {{ if .Metadata.ShortNames }}
+            <div class="meta-item">
+                <label>Short Names</label>
+                <span>{{ range .Metadata.ShortNames }}{{ . }} {{ end }}</span>
+            </div>
+            {{ end }}
+            {{ if .Metadata.Categories }}
+            <div class="meta-item">
+                <label>Categories</label>
+                <span>{{ range .Metadata.Categories }}{{ . }} {{ end }}</span>
+            </div>
+            {{ end }}
+        </div>
+        {{ if .Metadata.HasConversionWebhook }}
+        <div class="callout">
+            <strong>Conversion webhook:</strong> objects of this CRD are transformed by an external webhook when read or written in a version other than the storage version.
+        </div>
+        {{ end }}
+        {{ if .NoSchema }}
+        <div class="callout">
+            <strong>No schema:</strong> this CRD defines no OpenAPI v3 schema, so its fields are neither validated by the API server nor documented here.
+        </div>
+        {{ end }}
+        <div class="description">
+            {{ template "desc" .Description }}
+        </div>
+        {{ if .Required }}
+        <div class="required-fields">Required top-level fields: {{ range .Required }}<code>{{ . }}</code> {{ end }}</div>
+        {{ end }}
+    </div>
+{{ end }}
+
+{{ define "controls" }}
+    <div class="controls">
+        <div class="btn-group">
+            <button onclick="toggleAll(true)">Expand All</button>
+            <button onclick="toggleAll(false)">Collapse All</button>
+            <button onclick="toggleTheme()">Theme</button>
+        </div>
+        <input type="text" id="search-input" placeholder="Search fields..." onkeyup="filterFields()">
+    </div>
+{{ end }}
+
+{{ define "body" }}
+    {{ with .MetadataConstraints }}
+    <h2 class="section-title">Metadata{{ if .Required }} <span class="badge-req">Required</span>{{ end }}</h2>
+    {{ if .Description }}<div class="section-desc">{{ template "desc" .Description }}</div>{{ end }}
+    <div class="spec-container">
+        {{ template "fields" .Fields }}
+    </div>
+    {{ end }}
+
+    {{ if not .NoSchema }}
+    <h2 class="section-title">Spec{{ if .Spec.Required }} <span class="badge-req">Required</span>{{ end }}</h2>
+    {{ if .Spec.Description }}<div class="section-desc">{{ template "desc" .Spec.Description }}</div>{{ end }}
+    <div class="spec-container">
+        {{ template "fields" .Spec.Fields }}
+    </div>
+
+    {{ if .Status }}
+    <h2 class="section-title">Status{{ if .Status.Required }} <span class="badge-req">Required</span>{{ end }}</h2>
+    {{ if .Status.Description }}<div class="section-desc">{{ template "desc" .Status.Description }}</div>{{ end }}
+    <div class="spec-container">
+        {{ template "fields" .Status.Fields }}
+    </div>
+    {{ end }}
+    {{ end }}
+
+    {{ if .Examples }}
+    <div class="examples">
+        <h2>Examples</h2>
+        {{ if .ExamplesFromSchema }}<p class="examples-note">No instances exist in the cluster, so this example was generated from the schema.</p>{{ end }}
+        {{ range .Examples }}
+        <div class="example">{{ highlightYAML . }}</div>
+        {{ end }}
+    </div>
+    {{ end }}
+{{ end }}
+`
+
+// IndexMarkdownTemplate is the template for the Markdown index of exported CRDs.
+const IndexMarkdownTemplate = `# Custom Resource Definitions
+
+{{ .Total }} CRDs grouped by API group.
+{{ range .Groups }}
+## {{ .Name }}
+
+| Kind | Name |
+| :--- | :--- |
+{{- range .Entries }}
+| [{{ .Kind }}]({{ .File }}) | {{ .Name }} |
+{{- end }}
+{{ end }}`
+
+// IndexHTMLTemplate is the template for the HTML index of exported CRDs.
+const IndexHTMLTemplate = `
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{ if .Branding.Title }}{{ .Branding.Title }} - {{ end }}Custom Resource Definitions</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, Helvetica, Arial, sans-serif;
+            background-color: #f8fafc;
+            color: #0f172a;
+            line-height: 1.5;
+            margin: 0;
+        }
+        .container { max-width: 1000px; margin: 0 auto; padding: 2rem; }
+        .brand { display: flex; align-items: center; gap: 0.75rem; margin-bottom: 1rem; color: #64748b; font-weight: 600; }
+        .brand img { max-height: 40px; }
+        h1 { margin: 0 0 0.25rem; }
+        .subtitle { color: #64748b; margin-bottom: 2rem; }
+        .group {
+            background: #ffffff;
+            border: 1px solid #e2e8f0;
+            border-radius: 0.75rem;
+            padding: 1rem 1.5rem;
+            margin-bottom: 1rem;
+        }
+        .group h2 { font-size: 1.1rem; margin: 0 0 0.5rem; }
+        .group ul { list-style: none; margin: 0; padding: 0; }
+        .group li { padding: 0.25rem 0; }
+        .group a { color: #3b82f6; text-decoration: none; font-weight: 500; }
+        .group a:hover { text-decoration: underline; }
+        .name { color: #64748b; font-family: monospace; font-size: 0.85em; margin-left: 0.5rem; }
+{{ if .Branding.CSS }}
+        /* Custom CSS */
+{{ .Branding.CSS }}
+{{ end }}
+    </style>
+</head>
+<body>
+<div class="container">
+    {{ if or .Branding.LogoURL .Branding.Title }}
+    <div class="brand">
+        {{ if .Branding.LogoURL }}<img src="{{ .Branding.LogoURL }}" alt="{{ .Branding.Title }}">{{ end }}
+        {{ if .Branding.Title }}<span>{{ .Branding.Title }}</span>{{ end }}
+    </div>
+    {{ end }}
+    <h1>Custom Resource Definitions</h1>
+    <div class="subtitle">{{ .Total }} CRDs grouped by API group</div>
+    {{ range .Groups }}
+    <div class="group">
+        <h2>{{ .Name }}</h2>
+        <ul>
+            {{ range .Entries }}
+            <li><a href="{{ .File }}">{{ .Kind }}</a><span class="name">{{ .Name }}</span></li>
+            {{ end }}
+        </ul>
+    </div>
+    {{ end }}
+</div>
+</body>
+</html>
+`
+
+// ReportHTMLTemplate is the template for the landing page of a cluster report.
+const ReportHTMLTemplate = `
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{ if .Branding.Title }}{{ .Branding.Title }} - {{ end }}Cluster Report: {{ .Cluster.ClusterName }}</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, Helvetica, Arial, sans-serif;
+            background-color: #f8fafc;
+            color: #0f172a;
+            line-height: 1.5;
+            margin: 0;
+        }
+        .container { max-width: 1000px; margin: 0 auto; padding: 2rem; }
+        .brand { display: flex; align-items: center; gap: 0.75rem; margin-bottom: 1rem; color: #64748b; font-weight: 600; }
+        .brand img { max-height: 40px; }
+        h1 { margin: 0 0 0.25rem; }
+        .subtitle { color: #64748b; margin-bottom: 2rem; }
+        .stats { display: grid; grid-template-columns: repeat(auto-fit, minmax(180px, 1fr)); gap: 1rem; margin-bottom: 2rem; }
+        .stat { background: #ffffff; border: 1px solid #e2e8f0; border-radius: 0.75rem; padding: 1rem 1.5rem; }
+        .stat .label { color: #64748b; font-size: 0.8rem; text-transform: uppercase; letter-spacing: 0.05em; }
+        .stat .value { font-size: 1.25rem; font-weight: 600; word-break: break-all; }
+        .notice {
+            background: #fffbeb;
+            border: 1px solid #fcd34d;
+            border-radius: 0.75rem;
+            padding: 1rem 1.5rem;
+            margin-bottom: 1rem;
+        }
+        .notice h2 { font-size: 1rem; margin: 0 0 0.5rem; }
+        .notice ul { margin: 0; padding-left: 1.25rem; }
+        .toc { margin-bottom: 2rem; }
+        .toc a { color: #3b82f6; text-decoration: none; margin-right: 1rem; white-space: nowrap; }
+        .group {
+            background: #ffffff;
+            border: 1px solid #e2e8f0;
+            border-radius: 0.75rem;
+            padding: 1rem 1.5rem;
+            margin-bottom: 1rem;
+        }
+        .group h2 { font-size: 1.1rem; margin: 0 0 0.5rem; }
+        .group table { width: 100%; border-collapse: collapse; }
+        .group td { padding: 0.25rem 0; border-top: 1px solid #f1f5f9; }
+        .group a { color: #3b82f6; text-decoration: none; font-weight: 500; }
+        .group a:hover { text-decoration: underline; }
+        .name { color: #64748b; font-family: monospace; font-size: 0.85em; }
+        .instances { text-align: right; color: #64748b; white-space: nowrap; }
+        .unused { color: #94a3b8; font-style: italic; }
+        .footer { color: #94a3b8; font-size: 0.8rem; margin-top: 2rem; }
+{{ if .Branding.CSS }}
+        /* Custom CSS */
+{{ .Branding.CSS }}
+{{ end }}
+    </style>
+</head>
+<body>
+<div class="container">
+    {{ if or .Branding.LogoURL .Branding.Title }}
+    <div class="brand">
+        {{ if .Branding.LogoURL }}<img src="{{ .Branding.LogoURL }}" alt="{{ .Branding.Title }}">{{ end }}
+        {{ if .Branding.Title }}<span>{{ .Branding.Title }}</span>{{ end }}
+    </div>
+    {{ end }}
+    <h1>Cluster Report: {{ .Cluster.ClusterName }}</h1>
+    <div class="subtitle">The Custom Resource Definitions of the cluster, grouped by API group</div>
+
+    <div class="stats">
+        <div class="stat"><div class="label">Kubernetes</div><div class="value">{{ .Cluster.ServerVersion }}</div></div>
+        <div class="stat"><div class="label">CRDs</div><div class="value">{{ .Total }}</div></div>
+        <div class="stat"><div class="label">API Groups</div><div class="value">{{ len .Groups }}</div></div>
+        <div class="stat"><div class="label">Custom Resources</div><div class="value">{{ .Instances }}</div></div>
+    </div>
+
+    {{ if .Cluster.Warnings }}
+    <div class="notice">
+        <h2>Warnings</h2>
+        <ul>{{ range .Cluster.Warnings }}<li>{{ . }}</li>{{ end }}</ul>
+    </div>
+    {{ end }}
+    {{ if .Skipped }}
+    <div class="notice">
+        <h2>CRDs without documentation</h2>
+        <ul>{{ range .Skipped }}<li>{{ . }}</li>{{ end }}</ul>
+    </div>
+    {{ end }}
+
+    {{ if .Groups }}
+    <div class="toc">{{ range .Groups }}<a href="#{{ .Name }}">{{ .Name }}</a> {{ end }}</div>
+    {{ end }}
+    {{ range .Groups }}
+    <div class="group" id="{{ .Name }}">
+        <h2>{{ .Name }}</h2>
+        <table>
+            {{ range .Entries }}
+            <tr>
+                <td><a href="{{ .File }}">{{ .Kind }}</a></td>
+                <td class="name">{{ .Name }}</td>
+                <td class="instances">{{ if .Instances }}{{ if eq (deref .Instances) 0 }}<span class="unused">Not in use</span>{{ else }}{{ deref .Instances }} in use{{ end }}{{ end }}</td>
+            </tr>
+            {{ end }}
+        </table>
+    </div>
+    {{ end }}
+    <div class="footer">Generated {{ .Generated }}</div>
+</div>
+</body>
+</html>
 `
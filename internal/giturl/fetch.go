@@ -0,0 +1,164 @@
+package giturl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const (
+	// MaxFetchSize caps how much of a remote file is read.
+	MaxFetchSize = 10 * 1024 * 1024 // 10MB
+
+	defaultFetchTimeout = 30 * time.Second
+)
+
+// FetchOptions tunes how remote files are fetched.
+type FetchOptions struct {
+	// Timeout bounds the whole request, including reading the body.
+	Timeout time.Duration
+	// AllowPrivate permits fetching from loopback, link-local and private addresses.
+	// Leave it unset when the URL comes from an untrusted client, to prevent SSRF
+	// against cluster-internal services and cloud metadata endpoints.
+	AllowPrivate bool
+	// Allow permits fetching from these prefixes even when they are private, e.g. an
+	// internal Git server.
+	Allow []netip.Prefix
+	// Deny forbids fetching from these prefixes, even when they are allowed otherwise.
+	Deny []netip.Prefix
+}
+
+// ErrBlockedAddress is returned when a URL resolves to an address that is not allowed.
+var ErrBlockedAddress = errors.New("fetching from private, loopback, link-local or denied addresses is not allowed")
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which net.IP does not
+// consider private but which is as internal as the RFC 1918 ranges.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Fetch downloads the content at rawURL. Only http and https URLs are accepted,
+// and content larger than MaxFetchSize is rejected.
+func Fetch(ctx context.Context, rawURL string, opts FetchOptions) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported URL scheme %q, only http and https are allowed", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid URL: missing host")
+	}
+
+	client := NewHTTPClient(opts)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, MaxFetchSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content: %w", err)
+	}
+	if len(content) > MaxFetchSize {
+		return nil, fmt.Errorf("content exceeds the %d bytes limit", MaxFetchSize)
+	}
+	return content, nil
+}
+
+// NewHTTPClient returns an HTTP client honouring the timeout and address restrictions of opts.
+// It is meant for any request to a URL supplied by a user, not only for Fetch.
+func NewHTTPClient(opts FetchOptions) *http.Client {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultFetchTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+	if !opts.AllowPrivate || len(opts.Deny) > 0 {
+		// The address is checked when dialing, after DNS resolution, so redirects
+		// and DNS rebinding cannot be used to reach a blocked address.
+		dialer := &net.Dialer{Timeout: timeout, Control: opts.checkAddress}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = nil
+		transport.DialContext = dialer.DialContext
+		client.Transport = transport
+	}
+	return client
+}
+
+// checkAddress rejects connections to the denied prefixes and, unless private addresses or
+// the prefix of the address are allowed, to loopback, link-local (including the
+// 169.254.169.254 metadata endpoint), RFC 1918 / unique local, carrier-grade NAT and
+// unspecified addresses.
+func (opts FetchOptions) checkAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	// IPv4-mapped IPv6 addresses such as ::ffff:127.0.0.1 reach the IPv4 address.
+	addr = addr.Unmap()
+
+	if containsAddr(opts.Deny, addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+	}
+	if opts.AllowPrivate || containsAddr(opts.Allow, addr) {
+		return nil
+	}
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+	}
+	return nil
+}
+
+// containsAddr reports whether any of prefixes contains addr.
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePrefixes parses CIDR prefixes such as 10.1.0.0/16, taking a single address as the
+// prefix of just that address.
+func ParsePrefixes(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if addr, err := netip.ParseAddr(value); err == nil {
+			addr = addr.Unmap()
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address or CIDR prefix %q: %w", value, err)
+		}
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
@@ -19,9 +19,12 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"os"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 	"gopkg.in/yaml.v2"
@@ -46,6 +49,68 @@ import (
 	"github.com/pehlicd/crd-wizard/internal/models"
 )
 
+const (
+	defaultInstanceCountTimeout = 5 * time.Second
+	defaultEventsTimeout        = 10 * time.Second
+
+	// DefaultQPS and DefaultBurst are the client-side rate limits applied to API requests.
+	DefaultQPS   = 100
+	DefaultBurst = 150
+)
+
+// Options tunes how the client talks to the API server.
+type Options struct {
+	// InstanceCountTimeout bounds the list call used to count the instances of a CRD.
+	// Setting it too low undercounts instances of CRDs with many objects.
+	InstanceCountTimeout time.Duration
+	// EventsTimeout bounds the list call used to fetch events.
+	EventsTimeout time.Duration
+	// GraphCacheTTL is how long the cluster scan behind resource graphs is reused by
+	// later graph builds. Zero disables the cache.
+	GraphCacheTTL time.Duration
+	// GraphKinds restricts the cluster scan behind resource graphs to these kinds and the
+	// kinds their owners are of. Empty scans every listable resource type.
+	GraphKinds []string
+	// GraphMaxObjects caps the number of objects held in memory by a resource graph scan.
+	// Objects beyond the cap are skipped and the graph is partial. Zero means no cap.
+	GraphMaxObjects int
+	// GraphMaxObjectsPerType caps the objects listed for a single resource type. Zero means no cap.
+	GraphMaxObjectsPerType int
+	// GraphCustomResourcesOnly restricts the cluster scan behind resource graphs to resource
+	// types defined by CRDs, leaving out built-in resources such as Pods or Secrets.
+	GraphCustomResourcesOnly bool
+	// GraphGroups restricts the cluster scan behind resource graphs to these API groups,
+	// CoreGroup names the core group. Empty scans every group.
+	GraphGroups []string
+	// ReadOnly rejects every create, update, patch and delete request sent to the cluster.
+	ReadOnly bool
+	// InsecureSkipTLSVerify disables verification of the API server certificate.
+	InsecureSkipTLSVerify bool
+	// CertificateAuthority is the path to a CA bundle that replaces the one from the kubeconfig.
+	CertificateAuthority string
+	// QPS is the sustained rate of requests per second the client sends to the API server.
+	// Instance counting and resource graph scans issue many requests and are bound by it.
+	QPS float32
+	// Burst is the number of requests that may exceed QPS for short periods.
+	Burst int
+}
+
+func (o Options) withDefaults() Options {
+	if o.InstanceCountTimeout <= 0 {
+		o.InstanceCountTimeout = defaultInstanceCountTimeout
+	}
+	if o.EventsTimeout <= 0 {
+		o.EventsTimeout = defaultEventsTimeout
+	}
+	if o.QPS <= 0 {
+		o.QPS = DefaultQPS
+	}
+	if o.Burst <= 0 {
+		o.Burst = DefaultBurst
+	}
+	return o
+}
+
 type Client struct {
 	ExtensionsClient *apiextensionsclientset.Clientset
 	DynamicClient    dynamic.Interface
@@ -53,18 +118,37 @@ type Client struct {
 	DiscoveryClient  discovery.DiscoveryInterface
 	APIExtClient     *apiextensionsclientset.Clientset
 	ClusterName      string
+	opts             Options
 	log              *logger.Logger
+	namespaces       namespaceCache
+	crdInformer      crdInformer
+	crdAPI           crdAPI
+	graphScan        graphScanCache
 }
 
-func NewClient(kubeconfigPath, contextName string, log *logger.Logger) (*Client, error) {
+func NewClient(kubeconfigPath, contextName string, opts Options, log *logger.Logger) (*Client, error) {
 	config, clusterName, err := buildConfig(kubeconfigPath, contextName)
 	if err != nil {
 		log.Error("error building config", "err", err)
 		return nil, err
 	}
 
-	config.QPS = 100
-	config.Burst = 150
+	opts = opts.withDefaults()
+	config.QPS = opts.QPS
+	config.Burst = opts.Burst
+
+	if err := applyTLSOverrides(config, opts); err != nil {
+		return nil, err
+	}
+	if opts.InsecureSkipTLSVerify {
+		log.Warn("TLS verification of the API server is disabled", "context", contextName)
+	}
+
+	if opts.ReadOnly {
+		config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return &readOnlyTransport{next: rt, log: log}
+		})
+	}
 
 	extensionsClient, err := apiextensionsclientset.NewForConfig(config)
 	if err != nil {
@@ -98,10 +182,33 @@ func NewClient(kubeconfigPath, contextName string, log *logger.Logger) (*Client,
 		DiscoveryClient:  discoveryClient,
 		APIExtClient:     apiExtClient,
 		ClusterName:      clusterName,
+		opts:             opts,
 		log:              log,
 	}, nil
 }
 
+// applyTLSOverrides replaces the CA of the API server from the kubeconfig, or disables
+// verifying its certificate, the way kubectl's flags of the same names do.
+func applyTLSOverrides(config *rest.Config, opts Options) error {
+	if opts.InsecureSkipTLSVerify && opts.CertificateAuthority != "" {
+		return fmt.Errorf("--insecure-skip-tls-verify and --certificate-authority cannot be used together")
+	}
+	if opts.CertificateAuthority != "" {
+		if _, err := os.Stat(opts.CertificateAuthority); err != nil {
+			return fmt.Errorf("error reading certificate authority: %w", err)
+		}
+		config.CAFile = opts.CertificateAuthority
+		config.CAData = nil
+	}
+	if opts.InsecureSkipTLSVerify {
+		// client-go refuses a CA together with the insecure flag.
+		config.Insecure = true
+		config.CAFile = ""
+		config.CAData = nil
+	}
+	return nil
+}
+
 func buildConfig(kubeconfigPath, contextName string) (*rest.Config, string, error) {
 	// First, try in-cluster config
 	config, err := rest.InClusterConfig()
@@ -164,26 +271,28 @@ func (c *Client) GetClusterInfo() (models.ClusterInfo, error) {
 		return models.ClusterInfo{}, fmt.Errorf("failed to get server version: %w", err)
 	}
 
-	crdList, err := c.ExtensionsClient.ApiextensionsV1().CustomResourceDefinitions().List(context.Background(), metav1.ListOptions{})
+	info := models.ClusterInfo{
+		ClusterName:   c.ClusterName,
+		ServerVersion: versionInfo.GitVersion,
+		Warnings:      versionWarnings(versionInfo.GitVersion),
+	}
+
+	crds, err := c.ListCRDs(context.Background(), metav1.ListOptions{})
 	if err != nil {
 		return models.ClusterInfo{}, fmt.Errorf("failed to fetch CRDs: %w", err)
 	}
-
-	return models.ClusterInfo{
-		ClusterName:   c.ClusterName,
-		ServerVersion: versionInfo.GitVersion,
-		NumCRDs:       len(crdList.Items),
-	}, nil
+	info.NumCRDs = len(crds)
+	return info, nil
 }
 
 func (c *Client) GetCRDs(ctx context.Context) ([]models.CRD, error) {
-	crdList, err := c.ExtensionsClient.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	crds, err := c.ListCRDs(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch CRDs: %w", err)
 	}
-	uiCrds := make([]models.CRD, len(crdList.Items))
+	uiCrds := make([]models.CRD, len(crds))
 	var g errgroup.Group
-	for i, crd := range crdList.Items {
+	for i, crd := range crds {
 		i, crd := i, crd
 		g.Go(func() error {
 			instanceCount := c.CountCRDInstances(ctx, crd)
@@ -198,7 +307,7 @@ func (c *Client) GetCRDs(ctx context.Context) ([]models.CRD, error) {
 }
 
 func (c *Client) GetCRsForCRD(ctx context.Context, crdName string) ([]unstructured.Unstructured, error) {
-	crd, err := c.ExtensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, crdName, metav1.GetOptions{})
+	crd, err := c.getCRD(ctx, crdName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get CRD %s: %w", crdName, err)
 	}
@@ -214,19 +323,9 @@ func (c *Client) GetCRsForCRD(ctx context.Context, crdName string) ([]unstructur
 }
 
 func (c *Client) GetSingleCR(ctx context.Context, crdName, namespace, name string) (*unstructured.Unstructured, error) {
-	crd, err := c.ExtensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, crdName, metav1.GetOptions{})
+	resource, err := c.crResource(ctx, crdName, namespace)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get CRD %s: %w", crdName, err)
-	}
-	gvr, _ := getGVRFromCRD(*crd)
-	if gvr.Resource == "" {
-		return nil, fmt.Errorf("could not determine GVR for CRD %s", crdName)
-	}
-	var resource dynamic.ResourceInterface
-	if crd.Spec.Scope == apiextensionsv1.NamespaceScoped {
-		resource = c.DynamicClient.Resource(gvr).Namespace(namespace)
-	} else {
-		resource = c.DynamicClient.Resource(gvr)
+		return nil, err
 	}
 
 	unstructuredCR, err := resource.Get(ctx, name, metav1.GetOptions{})
@@ -239,13 +338,26 @@ func (c *Client) GetSingleCR(ctx context.Context, crdName, namespace, name strin
 	return unstructuredCR, nil
 }
 
-// GetFullCRD retrieves the complete CustomResourceDefinition object from the cluster.
-func (c *Client) GetFullCRD(ctx context.Context, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
-	crd, err := c.APIExtClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+// crResource returns the dynamic client for the custom resources of a CRD, scoped to
+// namespace when the CRD is namespaced.
+func (c *Client) crResource(ctx context.Context, crdName, namespace string) (dynamic.ResourceInterface, error) {
+	crd, err := c.getCRD(ctx, crdName)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get CRD %s: %w", crdName, err)
+	}
+	gvr, _ := getGVRFromCRD(*crd)
+	if gvr.Resource == "" {
+		return nil, fmt.Errorf("could not determine GVR for CRD %s", crdName)
+	}
+	if crd.Spec.Scope == apiextensionsv1.NamespaceScoped {
+		return c.DynamicClient.Resource(gvr).Namespace(namespace), nil
 	}
-	return crd, nil
+	return c.DynamicClient.Resource(gvr), nil
+}
+
+// GetFullCRD retrieves the complete CustomResourceDefinition object from the cluster.
+func (c *Client) GetFullCRD(ctx context.Context, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	return c.getCRD(ctx, name)
 }
 
 func (c *Client) GetEvents(ctx context.Context, crdName, resourceUID string) ([]corev1.Event, error) {
@@ -259,7 +371,7 @@ func (c *Client) GetEvents(ctx context.Context, crdName, resourceUID string) ([]
 }
 
 func (c *Client) getEventsForUID(ctx context.Context, uid string) ([]corev1.Event, error) {
-	allEvents, err := c.CoreClient.CoreV1().Events("").List(ctx, metav1.ListOptions{TimeoutSeconds: &[]int64{10}[0]})
+	allEvents, err := c.CoreClient.CoreV1().Events("").List(ctx, metav1.ListOptions{TimeoutSeconds: timeoutSeconds(c.opts.EventsTimeout)})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list events: %w", err)
 	}
@@ -285,7 +397,7 @@ func (c *Client) getEventsForCRD(ctx context.Context, crdName string) ([]corev1.
 	for _, item := range crList {
 		crUIDs[item.GetUID()] = true
 	}
-	allEvents, err := c.CoreClient.CoreV1().Events("").List(ctx, metav1.ListOptions{})
+	allEvents, err := c.CoreClient.CoreV1().Events("").List(ctx, metav1.ListOptions{TimeoutSeconds: timeoutSeconds(c.opts.EventsTimeout)})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list events: %w", err)
 	}
@@ -303,13 +415,22 @@ func (c *Client) CountCRDInstances(ctx context.Context, crd apiextensionsv1.Cust
 	if gvr.Resource == "" {
 		return 0
 	}
-	list, err := c.DynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{TimeoutSeconds: &[]int64{5}[0]})
+	list, err := c.DynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{TimeoutSeconds: timeoutSeconds(c.opts.InstanceCountTimeout)})
 	if err != nil {
 		return 0
 	}
 	return len(list.Items)
 }
 
+// timeoutSeconds converts d into the whole-second form expected by ListOptions, rounding up to at least one second.
+func timeoutSeconds(d time.Duration) *int64 {
+	secs := int64((d + time.Second - 1) / time.Second)
+	if secs < 1 {
+		secs = 1
+	}
+	return &secs
+}
+
 func getGVRFromCRD(crd apiextensionsv1.CustomResourceDefinition) (schema.GroupVersionResource, string) {
 	storageVersion := ""
 	for _, v := range crd.Spec.Versions {
@@ -376,14 +497,51 @@ func (c *Client) DryRun(ctx context.Context, manifest string) error {
 	return nil
 }
 
-// fetchCRDExamples connects to the cluster and retrieves live examples of a given CRD.
-// It uses the discovery client to find the correct resource name for the given GVK.
-func (c *Client) FetchCRDExamples(ctx context.Context, group, version, kind string) (string, error) {
+// DefaultExampleLimit is the number of live examples fetched when ExampleOptions.Limit is not set.
+const DefaultExampleLimit = 3
+
+// ExampleOptions controls which live instances are sampled as examples of a CRD.
+type ExampleOptions struct {
+	// Limit is the maximum number of instances returned, DefaultExampleLimit when zero.
+	Limit int64
+	// Namespace restricts the sampling to a single namespace, all namespaces when empty.
+	Namespace string
+}
+
+// FetchCRDExamples connects to the cluster and retrieves live examples of a given CRD
+// as a single YAML stream. It uses the discovery client to find the correct resource
+// name for the given GVK.
+func (c *Client) FetchCRDExamples(ctx context.Context, group, version, kind string, opts ExampleOptions) (string, error) {
+	items, err := c.ListCRDExamples(ctx, group, version, kind, opts)
+	if err != nil {
+		return "", err
+	}
+
+	var examples []string
+	for _, item := range items {
+		yamlBytes, err := yaml.Marshal(item.Object)
+		if err != nil {
+			c.log.Warn("failed to marshal resource item to YAML", "name", item.GetName(), "err", err)
+			continue
+		}
+		examples = append(examples, string(yamlBytes))
+	}
+
+	return strings.Join(examples, "\n---\n"), nil
+}
+
+// ListCRDExamples lists live instances of a given CRD as sampled by opts, with the
+// metadata and status that are irrelevant for a new example removed.
+func (c *Client) ListCRDExamples(ctx context.Context, group, version, kind string, opts ExampleOptions) ([]unstructured.Unstructured, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultExampleLimit
+	}
+
 	// 1. Use the Discovery client to find the API resource.
 	// This is the robust way to find the plural name (e.g., "certificates").
 	apiResource, err := c.findAPIResource(group, version, kind)
 	if err != nil {
-		return "", fmt.Errorf("could not find API resource for %s/%s, Kind=%s: %w", group, version, kind, err)
+		return nil, fmt.Errorf("could not find API resource for %s/%s, Kind=%s: %w", group, version, kind, err)
 	}
 
 	gvr := schema.GroupVersionResource{
@@ -393,17 +551,16 @@ func (c *Client) FetchCRDExamples(ctx context.Context, group, version, kind stri
 	}
 
 	// 2. Use the Dynamic client to list instances of that resource.
-	// We list across all namespaces.
-	list, err := c.DynamicClient.Resource(gvr).Namespace("").List(ctx, metav1.ListOptions{Limit: 3})
-	if err != nil {
-		return "", fmt.Errorf("failed to list CRD resources for %s: %w", gvr, err)
+	// Cluster-scoped resources ignore the namespace.
+	namespace := opts.Namespace
+	if !apiResource.Namespaced {
+		namespace = ""
 	}
-
-	if len(list.Items) == 0 {
-		return "", nil // No examples found, which is not an error.
+	list, err := c.DynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{Limit: opts.Limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CRD resources for %s: %w", gvr, err)
 	}
 
-	var examples []string
 	for _, item := range list.Items {
 		// Clean up metadata that is irrelevant for a new example.
 		// This makes the context cleaner for the LLM.
@@ -414,16 +571,9 @@ func (c *Client) FetchCRDExamples(ctx context.Context, group, version, kind stri
 		unstructured.RemoveNestedField(item.Object, "metadata", "managedFields")
 		unstructured.RemoveNestedField(item.Object, "metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration")
 		unstructured.RemoveNestedField(item.Object, "status") // Status is not part of the desired state.
-
-		yamlBytes, err := yaml.Marshal(item.Object)
-		if err != nil {
-			fmt.Printf("Warning: failed to marshal resource item to YAML: %v\n", err)
-			continue
-		}
-		examples = append(examples, string(yamlBytes))
 	}
 
-	return strings.Join(examples, "\n---\n"), nil
+	return list.Items, nil
 }
 
 // findAPIResource uses the discovery client to find the correct APIResource definition.
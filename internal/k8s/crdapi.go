@@ -0,0 +1,127 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/pehlicd/crd-wizard/internal/models"
+)
+
+// crdAPIGroupVersion is the CRD API the tool prefers, clusters older than 1.16 only serve v1beta1.
+const crdAPIGroupVersion = "apiextensions.k8s.io/v1"
+
+// ErrInvalidSelector is returned by ListCRDsFiltered for a label selector that cannot be parsed.
+var ErrInvalidSelector = errors.New("invalid label selector")
+
+// crdAPI remembers which apiextensions version the cluster serves CRDs through.
+type crdAPI struct {
+	once    sync.Once
+	v1beta1 bool
+}
+
+// usesV1beta1 reports whether CRDs have to be read through apiextensions.k8s.io/v1beta1.
+// The answer is looked up once via discovery. If discovery fails for any other reason than
+// v1 not being served, v1 is assumed and the CRD calls themselves report the error.
+func (c *Client) usesV1beta1() bool {
+	c.crdAPI.once.Do(func() {
+		_, err := c.DiscoveryClient.ServerResourcesForGroupVersion(crdAPIGroupVersion)
+		if apierrors.IsNotFound(err) {
+			c.crdAPI.v1beta1 = true
+			c.log.Warn("cluster does not serve apiextensions.k8s.io/v1, falling back to v1beta1 CRDs", "cluster", c.ClusterName)
+		}
+	})
+	return c.crdAPI.v1beta1
+}
+
+// ListCRDs lists the CRDs of the cluster. On clusters that only serve v1beta1 they are
+// converted into their v1 form.
+func (c *Client) ListCRDs(ctx context.Context, opts metav1.ListOptions) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	if !c.usesV1beta1() {
+		list, err := c.ExtensionsClient.ApiextensionsV1().CustomResourceDefinitions().List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	}
+
+	list, err := c.ExtensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	crds := make([]apiextensionsv1.CustomResourceDefinition, 0, len(list.Items))
+	for _, item := range list.Items {
+		crd, err := models.FromV1beta1CRD(item)
+		if err != nil {
+			c.log.Warn("skipping CRD", "name", item.Name, "err", err)
+			continue
+		}
+		crds = append(crds, crd)
+	}
+	return crds, nil
+}
+
+// getCRD gets a single CRD, converted into its v1 form on clusters that only serve v1beta1.
+func (c *Client) getCRD(ctx context.Context, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	if !c.usesV1beta1() {
+		return c.ExtensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+	}
+
+	item, err := c.ExtensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	crd, err := models.FromV1beta1CRD(*item)
+	if err != nil {
+		return nil, err
+	}
+	return &crd, nil
+}
+
+// CRDFilter selects CRDs by API group and labels.
+type CRDFilter struct {
+	// Groups keeps the CRDs of these API groups. Empty keeps every group.
+	Groups []string
+	// Selector is a label selector the CRDs must match, e.g. app.kubernetes.io/part-of=my-operator.
+	Selector string
+}
+
+// ListCRDsFiltered lists the CRDs matching filter. The selector is evaluated by the API server.
+func (c *Client) ListCRDsFiltered(ctx context.Context, filter CRDFilter) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	if _, err := labels.Parse(filter.Selector); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSelector, err)
+	}
+	crds, err := c.ListCRDs(ctx, metav1.ListOptions{LabelSelector: filter.Selector})
+	if err != nil {
+		return nil, err
+	}
+	if len(filter.Groups) == 0 {
+		return crds, nil
+	}
+	return slices.DeleteFunc(crds, func(crd apiextensionsv1.CustomResourceDefinition) bool {
+		return !slices.Contains(filter.Groups, crd.Spec.Group)
+	}), nil
+}
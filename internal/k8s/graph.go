@@ -18,10 +18,12 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,22 +34,189 @@ import (
 	"github.com/pehlicd/crd-wizard/internal/models"
 )
 
+// DefaultGraphKinds are the kinds that usually take part in the graph of a custom resource.
+var DefaultGraphKinds = []string{
+	"Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Job", "CronJob", "Pod",
+	"Service", "Ingress", "ConfigMap", "Secret", "PersistentVolumeClaim", "ServiceAccount",
+}
+
+// CoreGroup names the core API group ("") in group filters.
+const CoreGroup = "core"
+
+// GraphOptions tunes how a resource graph is built.
+type GraphOptions struct {
+	// Progress, when set, is called after each resource type of the cluster scan has been
+	// listed with the number of resource types scanned so far and the total number.
+	Progress func(scanned, total int)
+	// Kinds are scanned in addition to the kinds the client restricts graphs to,
+	// typically the kind of the start resource. They are ignored when graphs are not restricted.
+	Kinds []string
+	// CustomResourcesOnly only scans resource types defined by CRDs, in addition to
+	// Options.GraphCustomResourcesOnly of the client.
+	CustomResourcesOnly bool
+	// Groups only scans these API groups, replacing Options.GraphGroups of the client when set.
+	Groups []string
+}
+
+// graphScope is the part of the cluster a graph scan covers.
+type graphScope struct {
+	customOnly bool
+	groups     []string
+	// kinds are the kinds a restricted scan starts from, nil when the scan is not restricted.
+	kinds []string
+}
+
+// scope combines the scan scope of the client with the one requested for this graph.
+func (b *graphBuilder) scope() graphScope {
+	scope := graphScope{
+		customOnly: b.client.opts.GraphCustomResourcesOnly || b.opts.CustomResourcesOnly,
+		groups:     b.client.opts.GraphGroups,
+	}
+	if len(b.opts.Groups) > 0 {
+		scope.groups = b.opts.Groups
+	}
+	if b.client.RestrictsGraphKinds() {
+		scope.kinds = append(slices.Clone(b.client.opts.GraphKinds), b.opts.Kinds...)
+	}
+	return scope
+}
+
+// key identifies the scope in the scan cache, scans of different scopes are not shared.
+func (s graphScope) key() string {
+	groups := slices.Clone(s.groups)
+	slices.Sort(groups)
+	kinds := make([]string, 0, len(s.kinds))
+	for _, kind := range s.kinds {
+		kinds = append(kinds, strings.ToLower(kind))
+	}
+	slices.Sort(kinds)
+	kinds = slices.Compact(kinds)
+	return fmt.Sprintf("custom=%t groups=%s kinds=%s", s.customOnly, strings.Join(groups, ","), strings.Join(kinds, ","))
+}
+
+// filter drops the resource types outside the scope.
+func (b *graphBuilder) filter(scope graphScope, resources []graphResource) []graphResource {
+	if !scope.customOnly && len(scope.groups) == 0 {
+		return resources
+	}
+
+	var custom map[schema.GroupResource]bool
+	if scope.customOnly {
+		crds, err := b.client.ListCRDsCached(b.ctx)
+		if err != nil {
+			// Without the CRDs nothing is known to be custom, scanning everything is the safe choice.
+			b.client.log.Warn("could not list CRDs, scanning built-in resources too", "err", err)
+		} else {
+			custom = make(map[schema.GroupResource]bool, len(crds))
+			for _, crd := range crds {
+				custom[schema.GroupResource{Group: crd.Spec.Group, Resource: crd.Spec.Names.Plural}] = true
+			}
+		}
+	}
+
+	filtered := resources[:0]
+	for _, r := range resources {
+		if custom != nil && !custom[r.gvr.GroupResource()] {
+			continue
+		}
+		if len(scope.groups) > 0 && !slices.ContainsFunc(scope.groups, func(group string) bool {
+			return group == r.gvr.Group || (group == CoreGroup && r.gvr.Group == "")
+		}) {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
+// graphScanCache holds the last cluster scan of a client so that graphs built shortly
+// after one another do not list every resource in the cluster again.
+type graphScanCache struct {
+	mu          sync.Mutex
+	objectCache map[types.UID]unstructured.Unstructured
+	ownerIndex  map[types.UID][]types.UID
+	scannedAt   time.Time
+	scope       string
+}
+
 type graphBuilder struct {
 	client      *Client
 	ctx         context.Context
+	opts        GraphOptions
 	objectCache map[types.UID]unstructured.Unstructured
 	ownerIndex  map[types.UID][]types.UID
 	nodes       map[types.UID]models.Node
 	edges       map[string]models.Edge
 	queue       []types.UID
 	visited     map[types.UID]bool
+	// scanned and total count the resource types listed so far and scheduled to be listed.
+	scanned, total int
+	// capped is set once Options.GraphMaxObjects was reached and objects were skipped.
+	capped bool
+}
+
+// RestrictsGraphKinds reports whether resource graphs only scan Options.GraphKinds, so that
+// callers should pass the kind of the start resource in GraphOptions.Kinds.
+func (c *Client) RestrictsGraphKinds() bool {
+	return len(c.opts.GraphKinds) > 0
+}
+
+// CustomResourceKind returns the kind of the custom resource with the given UID, looking
+// through the instances of every CRD. It returns an empty kind when there is none.
+func (c *Client) CustomResourceKind(ctx context.Context, uid string) (string, error) {
+	crds, err := c.ListCRDsCached(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	g, groupCtx := errgroup.WithContext(ctx)
+	g.SetLimit(10)
+	var (
+		mu   sync.Mutex
+		kind string
+	)
+	for _, crd := range crds {
+		gvr, _ := getGVRFromCRD(crd)
+		if gvr.Resource == "" {
+			continue
+		}
+		g.Go(func() error {
+			list, err := c.DynamicClient.Resource(gvr).List(groupCtx, metav1.ListOptions{})
+			if err != nil {
+				// Types that cannot be listed cannot hold the resource as far as the graph goes.
+				return nil
+			}
+			for _, item := range list.Items {
+				if string(item.GetUID()) == uid {
+					mu.Lock()
+					kind = item.GetKind()
+					mu.Unlock()
+					return errFound
+				}
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil && !errors.Is(err, errFound) {
+		return "", err
+	}
+	return kind, nil
 }
 
+// errFound stops the search of CustomResourceKind once the resource is found.
+var errFound = errors.New("found")
+
 // GetResourceGraph builds and returns the relationship graph for a resource.
 func (c *Client) GetResourceGraph(ctx context.Context, startUID string) (*models.ResourceGraph, error) {
+	return c.GetResourceGraphWithOptions(ctx, startUID, GraphOptions{})
+}
+
+// GetResourceGraphWithOptions is like GetResourceGraph but lets the caller tune the build.
+func (c *Client) GetResourceGraphWithOptions(ctx context.Context, startUID string, opts GraphOptions) (*models.ResourceGraph, error) {
 	builder := &graphBuilder{
 		client:      c,
 		ctx:         ctx,
+		opts:        opts,
 		objectCache: make(map[types.UID]unstructured.Unstructured),
 		ownerIndex:  make(map[types.UID][]types.UID),
 		nodes:       make(map[types.UID]models.Node),
@@ -56,7 +225,7 @@ func (c *Client) GetResourceGraph(ctx context.Context, startUID string) (*models
 		visited:     make(map[types.UID]bool),
 	}
 
-	if err := builder.buildCaches(); err != nil {
+	if err := builder.loadCaches(types.UID(startUID)); err != nil {
 		return nil, fmt.Errorf("failed to build resource cache: %w", err)
 	}
 
@@ -69,8 +238,46 @@ func (c *Client) GetResourceGraph(ctx context.Context, startUID string) (*models
 	return builder.getResourceGraph(), nil
 }
 
-// buildCaches scans the cluster for all resources and builds the object and owner caches.
-func (b *graphBuilder) buildCaches() error {
+// loadCaches reuses the client's recent cluster scan of the same scope when it contains the
+// start resource, and scans the cluster otherwise. The caches are only read while tracing, so a scan can be
+// shared between builders.
+func (b *graphBuilder) loadCaches(startUID types.UID) error {
+	ttl := b.client.opts.GraphCacheTTL
+	if ttl <= 0 {
+		return b.buildCaches(startUID)
+	}
+
+	cache := &b.client.graphScan
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	scope := b.scope().key()
+	if cache.objectCache != nil && time.Since(cache.scannedAt) < ttl && cache.scope == scope {
+		if _, ok := cache.objectCache[startUID]; ok {
+			b.objectCache, b.ownerIndex = cache.objectCache, cache.ownerIndex
+			return nil
+		}
+	}
+
+	if err := b.buildCaches(startUID); err != nil {
+		return err
+	}
+	// A scan cut short by the object limit misses objects other graphs may need.
+	if b.capped {
+		return nil
+	}
+	cache.objectCache, cache.ownerIndex = b.objectCache, b.ownerIndex
+	cache.scannedAt = time.Now()
+	cache.scope = scope
+	return nil
+}
+
+// buildCaches scans the cluster for resources and builds the object and owner caches.
+// When the client restricts the graph to some kinds, only those kinds are listed, followed
+// by the kinds their owner references point to. If the start resource is not among them,
+// the rest of the cluster is scanned as well. Resource types outside the scope of the
+// client and of the graph options are never listed.
+func (b *graphBuilder) buildCaches(startUID types.UID) error {
 	apiResourceLists, err := b.client.DiscoveryClient.ServerPreferredResources()
 	if err != nil {
 		// The `ServerPreferredResources` endpoint can return partial results even on error.
@@ -79,10 +286,8 @@ func (b *graphBuilder) buildCaches() error {
 		b.client.log.Warn("could not discover all server resources", "err", err)
 	}
 
-	var mu sync.Mutex
-	g, ctx := errgroup.WithContext(b.ctx)
-	g.SetLimit(10)
-
+	// Collect the resource types first so that progress can be reported against the total.
+	var resources []graphResource
 	for _, list := range apiResourceLists {
 		gv, err := schema.ParseGroupVersion(list.GroupVersion)
 		if err != nil {
@@ -100,29 +305,125 @@ func (b *graphBuilder) buildCaches() error {
 				continue
 			}
 
-			gvr := gv.WithResource(resource.Name)
-			g.Go(func() error {
-				objList, err := b.client.DynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{})
-				if err != nil {
-					// It's common to lack permissions for some resources (e.g., cluster-scoped ones),
-					// so we log these as warnings and continue.
-					b.client.log.Warn("could not list", "gvr", gvr, "err", err)
-					return nil
+			resources = append(resources, graphResource{gvr: gv.WithResource(resource.Name), kind: resource.Kind})
+		}
+	}
+
+	resources = b.filter(b.scope(), resources)
+
+	if len(b.client.opts.GraphKinds) == 0 {
+		b.total = len(resources)
+		return b.scan(resources)
+	}
+
+	wanted := make(map[string]bool)
+	for _, kind := range b.scope().kinds {
+		wanted[strings.ToLower(kind)] = true
+	}
+	scanned := make(map[schema.GroupVersionResource]bool)
+	next := func(match func(graphResource) bool) []graphResource {
+		var batch []graphResource
+		for _, r := range resources {
+			if !scanned[r.gvr] && match(r) {
+				scanned[r.gvr] = true
+				batch = append(batch, r)
+			}
+		}
+		b.total += len(batch)
+		return batch
+	}
+
+	batch := next(func(r graphResource) bool { return wanted[strings.ToLower(r.kind)] })
+	for len(batch) > 0 {
+		if err := b.scan(batch); err != nil {
+			return err
+		}
+		// Follow the owner references of everything found so far to the kinds they point to.
+		owners := make(map[schema.GroupKind]bool)
+		for _, obj := range b.objectCache {
+			for _, owner := range obj.GetOwnerReferences() {
+				if gv, err := schema.ParseGroupVersion(owner.APIVersion); err == nil {
+					owners[gv.WithKind(owner.Kind).GroupKind()] = true
 				}
+			}
+		}
+		batch = next(func(r graphResource) bool { return owners[schema.GroupKind{Group: r.gvr.Group, Kind: r.kind}] })
+	}
+
+	if _, ok := b.objectCache[startUID]; !ok {
+		b.client.log.Warn("resource not found among the graph kinds, scanning the whole cluster", "uid", startUID)
+		return b.scan(next(func(graphResource) bool { return true }))
+	}
+	return nil
+}
+
+// graphResource is a listable resource type considered by the graph scan.
+type graphResource struct {
+	gvr  schema.GroupVersionResource
+	kind string
+}
+
+// scan lists every object of the given resource types into the object and owner caches.
+func (b *graphBuilder) scan(resources []graphResource) error {
+	var mu sync.Mutex
+	g, ctx := errgroup.WithContext(b.ctx)
+	g.SetLimit(10)
+
+	for _, resource := range resources {
+		// Stop queueing once the caller has gone away.
+		if ctx.Err() != nil {
+			break
+		}
+		gvr := resource.gvr
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			// A limit makes the API server return a single page, the rest of the type is skipped.
+			objList, err := b.client.DynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{Limit: int64(b.client.opts.GraphMaxObjectsPerType)})
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				// The list failed or was cut short by the cancellation, not by missing permissions.
+				return ctxErr
+			}
 
-				mu.Lock()
-				defer mu.Unlock()
-				for _, item := range objList.Items {
-					b.objectCache[item.GetUID()] = item
-					for _, owner := range item.GetOwnerReferences() {
-						b.ownerIndex[owner.UID] = append(b.ownerIndex[owner.UID], item.GetUID())
+			mu.Lock()
+			defer mu.Unlock()
+			b.scanned++
+			if b.opts.Progress != nil {
+				b.opts.Progress(b.scanned, b.total)
+			}
+			if err != nil {
+				// It's common to lack permissions for some resources (e.g., cluster-scoped ones),
+				// so we log these as warnings and continue.
+				b.client.log.Warn("could not list", "gvr", gvr, "err", err)
+				return nil
+			}
+
+			if objList.GetContinue() != "" {
+				b.client.log.Warn("too many objects, graph may be partial", "gvr", gvr, "limit", b.client.opts.GraphMaxObjectsPerType)
+			}
+
+			for _, item := range objList.Items {
+				if maxObjects := b.client.opts.GraphMaxObjects; maxObjects > 0 && len(b.objectCache) >= maxObjects {
+					if !b.capped {
+						b.capped = true
+						b.client.log.Warn("object limit of the resource graph scan reached, graph may be partial", "limit", maxObjects)
 					}
+					break
 				}
-				return nil
-			})
-		}
+				b.objectCache[item.GetUID()] = item
+				for _, owner := range item.GetOwnerReferences() {
+					b.ownerIndex[owner.UID] = append(b.ownerIndex[owner.UID], item.GetUID())
+				}
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
 	}
-	return g.Wait()
+	// A scan that stopped queueing early is incomplete and must not be cached.
+	return b.ctx.Err()
 }
 
 // traceGraph performs a breadth-first search to build the graph.
@@ -161,9 +462,11 @@ func (b *graphBuilder) traceGraph() {
 
 func (b *graphBuilder) addNode(obj unstructured.Unstructured) {
 	b.nodes[obj.GetUID()] = models.Node{
-		ID:    string(obj.GetUID()),
-		Label: obj.GetName(),
-		Type:  obj.GetKind(),
+		ID:         string(obj.GetUID()),
+		Label:      obj.GetName(),
+		Type:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		APIVersion: obj.GetAPIVersion(),
 	}
 }
 
@@ -177,8 +480,9 @@ func (b *graphBuilder) addEdge(source, target types.UID) {
 
 func (b *graphBuilder) getResourceGraph() *models.ResourceGraph {
 	graph := &models.ResourceGraph{
-		Nodes: make([]models.Node, 0, len(b.nodes)),
-		Edges: make([]models.Edge, 0, len(b.edges)),
+		Nodes:     make([]models.Node, 0, len(b.nodes)),
+		Edges:     make([]models.Edge, 0, len(b.edges)),
+		Truncated: b.capped,
 	}
 	for _, node := range b.nodes {
 		graph.Nodes = append(graph.Nodes, node)
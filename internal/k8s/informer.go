@@ -0,0 +1,89 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsinformers "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions"
+	apiextensionslisters "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// crdInformer keeps a local, watch-driven copy of the CRDs of a cluster.
+type crdInformer struct {
+	once sync.Once
+	// started is published once the informer runs, since informers of clusters other than
+	// the default one are started lazily while other requests may already list CRDs.
+	started atomic.Pointer[startedCRDInformer]
+}
+
+// startedCRDInformer is the lister of a running CRD informer.
+type startedCRDInformer struct {
+	lister    apiextensionslisters.CustomResourceDefinitionLister
+	hasSynced cache.InformerSynced
+}
+
+// StartCRDInformer starts watching CRDs in the background until stopCh is closed.
+// Calling it again is a no-op, so it is safe to call before every ListCRDsCached.
+// Clusters that only serve v1beta1 CRDs are not watched and always queried directly.
+func (c *Client) StartCRDInformer(stopCh <-chan struct{}) {
+	c.crdInformer.once.Do(func() {
+		if c.usesV1beta1() {
+			return
+		}
+		factory := apiextensionsinformers.NewSharedInformerFactory(c.ExtensionsClient, 0)
+		informer := factory.Apiextensions().V1().CustomResourceDefinitions()
+		started := &startedCRDInformer{lister: informer.Lister(), hasSynced: informer.Informer().HasSynced}
+		factory.Start(stopCh)
+		c.crdInformer.started.Store(started)
+		c.log.Debug("started CRD informer", "cluster", c.ClusterName)
+	})
+}
+
+// ListCRDsCached returns all CRDs sorted by name. Once the informer started by
+// StartCRDInformer has synced, they are read from its local cache; until then
+// the API server is queried directly.
+func (c *Client) ListCRDsCached(ctx context.Context) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	started := c.crdInformer.started.Load()
+	if started == nil || !started.hasSynced() {
+		crds, err := c.ListCRDs(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch CRDs: %w", err)
+		}
+		return crds, nil
+	}
+
+	cached, err := started.lister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cached CRDs: %w", err)
+	}
+	crds := make([]apiextensionsv1.CustomResourceDefinition, 0, len(cached))
+	for _, crd := range cached {
+		// Objects from the lister are shared with the informer and must not be mutated.
+		crds = append(crds, *crd.DeepCopy())
+	}
+	sort.Slice(crds, func(i, j int) bool { return crds[i].Name < crds[j].Name })
+	return crds, nil
+}
@@ -19,8 +19,10 @@ package k8s
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
@@ -28,27 +30,58 @@ import (
 	"github.com/pehlicd/crd-wizard/internal/logger"
 )
 
+const (
+	// contextRetryBaseDelay is the wait before a failed context is retried for the first time.
+	contextRetryBaseDelay = time.Second
+	// contextRetryMaxDelay caps the exponential backoff between retries of a failed context.
+	contextRetryMaxDelay = time.Minute
+)
+
 // ClusterManager manages multiple Kubernetes cluster connections.
-// It loads all contexts from kubeconfig at startup and provides
-// access to clients for each registered cluster.
+// It reads all contexts from kubeconfig at startup, but only builds the
+// client of a context the first time it is used.
 type ClusterManager struct {
-	clients        map[string]*Client // contextName -> Client
-	contextNames   []string           // ordered list of context names
+	clients        map[string]*Client         // contextName -> Client, for contexts used so far
+	failed         map[string]*contextFailure // contextName -> last failure, for contexts without a client
+	contextNames   []string                   // ordered list of context names
 	currentContext string
+	kubeconfigPath string
+	opts           Options
 	mu             sync.RWMutex
 	log            *logger.Logger
 }
 
+// contextFailure records why a client could not be built for a context and when to try again.
+type contextFailure struct {
+	err       error
+	attempts  int
+	nextRetry time.Time
+}
+
+// retryAfter returns the backoff after the given number of failed attempts.
+func retryAfter(attempts int) time.Duration {
+	delay := contextRetryBaseDelay
+	for i := 1; i < attempts && delay < contextRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, contextRetryMaxDelay)
+}
+
 // ClusterInfo represents basic information about a cluster for the API.
 type ClusterEntry struct {
 	Name      string `json:"name"`
 	IsCurrent bool   `json:"isCurrent"`
+	// Error is set when no client could be built for the context yet, it is retried on use.
+	Error string `json:"error,omitempty"`
 }
 
-// NewClusterManager creates a new ClusterManager and loads all contexts from kubeconfig.
+// NewClusterManager creates a new ClusterManager for all contexts from kubeconfig.
 // If kubeconfigPath is empty, it will use the default kubeconfig location.
-// Invalid contexts are skipped with warnings rather than failing the entire initialization.
-func NewClusterManager(kubeconfigPath string, log *logger.Logger) (*ClusterManager, error) {
+// Only the client of the current context is built up front, the others are built on first
+// use. Contexts that fail to load are kept and retried with backoff the next time they are
+// used, e.g. once a VPN needed to reach them is up. Initialization only fails when no
+// context can be loaded at all.
+func NewClusterManager(kubeconfigPath string, opts Options, log *logger.Logger) (*ClusterManager, error) {
 	// Expand tilde in path
 	if strings.HasPrefix(kubeconfigPath, "~/") {
 		home := homedir.HomeDir()
@@ -71,48 +104,105 @@ func NewClusterManager(kubeconfigPath string, log *logger.Logger) (*ClusterManag
 
 	manager := &ClusterManager{
 		clients:        make(map[string]*Client),
+		failed:         make(map[string]*contextFailure),
 		contextNames:   make([]string, 0),
 		currentContext: rawConfig.CurrentContext,
+		kubeconfigPath: kubeconfigPath,
+		opts:           opts,
 		log:            log,
 	}
 
-	// Load clients for all contexts
 	for contextName := range rawConfig.Contexts {
-		client, err := NewClient(kubeconfigPath, contextName, log)
-		if err != nil {
-			log.Warn("failed to load context, skipping", "context", contextName, "err", err)
-			continue
-		}
-		manager.clients[contextName] = client
 		manager.contextNames = append(manager.contextNames, contextName)
-		log.Debug("loaded context", "context", contextName)
 	}
 
-	if len(manager.clients) == 0 {
-		return nil, fmt.Errorf("no valid contexts found in kubeconfig")
-	}
+	// Only the current context is needed right away, the others are loaded on first use.
+	if _, err := manager.load(manager.currentContext); err != nil {
+		log.Warn("failed to load default context, will retry on use", "context", manager.currentContext, "err", err)
 
-	// Validate that current context is loaded
-	if _, ok := manager.clients[manager.currentContext]; !ok {
-		// Fall back to first available context
-		manager.currentContext = manager.contextNames[0]
-		log.Warn("default context not available, using first available", "context", manager.currentContext)
+		// Fall back to the first context that loads
+		manager.currentContext = ""
+		for _, name := range manager.contextNames {
+			if _, failed := manager.failed[name]; failed {
+				continue
+			}
+			if _, err := manager.load(name); err != nil {
+				log.Warn("failed to load context, will retry on use", "context", name, "err", err)
+				continue
+			}
+			manager.currentContext = name
+			log.Warn("default context not available, using first available", "context", name)
+			break
+		}
+		if manager.currentContext == "" {
+			return nil, fmt.Errorf("no valid contexts found in kubeconfig")
+		}
 	}
 
-	log.Info("cluster manager initialized", "clusters", len(manager.clients), "current", manager.currentContext)
+	log.Info("cluster manager initialized", "clusters", len(manager.contextNames), "current", manager.currentContext)
 
 	return manager, nil
 }
 
-// GetClient returns the client for a specific cluster context.
+// GetClient returns the client for a specific cluster context, building it on first use.
+// A context that failed to load is retried, unless its backoff has not elapsed yet.
 func (m *ClusterManager) GetClient(name string) (*Client, error) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-
 	client, ok := m.clients[name]
-	if !ok {
+	m.mu.RUnlock()
+	if ok {
+		return client, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.retry(name)
+}
+
+// retry returns the client of a context, building it if it was not used yet, or again if
+// it failed before and its backoff has elapsed. m.mu must be held for writing.
+func (m *ClusterManager) retry(name string) (*Client, error) {
+	if client, ok := m.clients[name]; ok {
+		return client, nil
+	}
+	if !slices.Contains(m.contextNames, name) {
 		return nil, fmt.Errorf("cluster %q not found", name)
 	}
+	failure, failed := m.failed[name]
+	if failed && time.Now().Before(failure.nextRetry) {
+		return nil, fmt.Errorf("cluster %q is unavailable, retrying in %s: %w", name, time.Until(failure.nextRetry).Round(time.Second), failure.err)
+	}
+
+	client, err := m.load(name)
+	if err != nil {
+		m.log.Warn("failed to load context", "context", name, "attempt", m.failed[name].attempts, "err", err)
+		return nil, fmt.Errorf("cluster %q is unavailable: %w", name, err)
+	}
+	if failed {
+		m.log.Info("loaded context after retry", "context", name)
+	}
+	return client, nil
+}
+
+// load builds the client of a context and records it, or records the failure and schedules
+// the next retry. m.mu must be held for writing, or m not yet shared.
+func (m *ClusterManager) load(name string) (*Client, error) {
+	client, err := NewClient(m.kubeconfigPath, name, m.opts, m.log)
+	if err != nil {
+		failure, ok := m.failed[name]
+		if !ok {
+			failure = &contextFailure{}
+			m.failed[name] = failure
+		}
+		failure.err = err
+		failure.attempts++
+		failure.nextRetry = time.Now().Add(retryAfter(failure.attempts))
+		return nil, err
+	}
+
+	delete(m.failed, name)
+	m.clients[name] = client
+	m.log.Debug("loaded context", "context", name)
 	return client, nil
 }
 
@@ -124,13 +214,13 @@ func (m *ClusterManager) GetCurrentClient() *Client {
 	return m.clients[m.currentContext]
 }
 
-// SetCurrentContext changes the current context.
+// SetCurrentContext changes the current context, retrying it first if it failed to load.
 func (m *ClusterManager) SetCurrentContext(name string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if _, ok := m.clients[name]; !ok {
-		return fmt.Errorf("cluster %q not found", name)
+	if _, err := m.retry(name); err != nil {
+		return err
 	}
 	m.currentContext = name
 	return nil
@@ -151,20 +241,24 @@ func (m *ClusterManager) ListClusters() []ClusterEntry {
 
 	clusters := make([]ClusterEntry, 0, len(m.contextNames))
 	for _, name := range m.contextNames {
-		clusters = append(clusters, ClusterEntry{
+		entry := ClusterEntry{
 			Name:      name,
 			IsCurrent: name == m.currentContext,
-		})
+		}
+		if failure, ok := m.failed[name]; ok {
+			entry.Error = failure.err.Error()
+		}
+		clusters = append(clusters, entry)
 	}
 	return clusters
 }
 
-// ClusterCount returns the number of loaded clusters.
-func (m *ClusterManager) ClusterCount() int {
+// ContextCount returns the number of contexts in the kubeconfig, whether loaded yet or not.
+func (m *ClusterManager) ContextCount() int {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	return len(m.clients)
+	return len(m.contextNames)
 }
 
 // ContextNames returns a copy of the ordered list of context names.
@@ -0,0 +1,63 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// namespacesTTL is how long a namespace listing is reused before asking the API server again.
+const namespacesTTL = 30 * time.Second
+
+// namespaceCache holds the last namespace listing of a client.
+type namespaceCache struct {
+	mu        sync.Mutex
+	names     []string
+	fetchedAt time.Time
+}
+
+// GetNamespaces returns the sorted names of all namespaces in the cluster.
+// The listing is cached briefly since it is mostly used to fill UI dropdowns.
+func (c *Client) GetNamespaces(ctx context.Context) ([]string, error) {
+	c.namespaces.mu.Lock()
+	defer c.namespaces.mu.Unlock()
+
+	if c.namespaces.names != nil && time.Since(c.namespaces.fetchedAt) < namespacesTTL {
+		return c.namespaces.names, nil
+	}
+
+	list, err := c.CoreClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	names := make([]string, 0, len(list.Items))
+	for _, ns := range list.Items {
+		names = append(names, ns.Name)
+	}
+	slices.Sort(names)
+
+	c.namespaces.names = names
+	c.namespaces.fetchedAt = time.Now()
+	return names, nil
+}
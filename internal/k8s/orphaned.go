@@ -0,0 +1,109 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package k8s
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pehlicd/crd-wizard/internal/models"
+)
+
+// DefaultOrphanStaleAfter is how long the status of an instance without owners may go
+// without updates before OrphanedInstances flags it.
+const DefaultOrphanStaleAfter = 7 * 24 * time.Hour
+
+// conditionTimeFields are the condition fields controllers commonly stamp when they update one.
+var conditionTimeFields = []string{"lastTransitionTime", "lastUpdateTime", "lastHeartbeatTime", "lastProbeTime"}
+
+// OrphanedInstances returns the instances that look left behind by an absent controller:
+// nothing owns them and their status has not been updated for staleAfter. These are
+// heuristics, so the result lists candidates for review along with the reasons they were flagged.
+func OrphanedInstances(objs []unstructured.Unstructured, staleAfter time.Duration, now time.Time) []models.OrphanedInstance {
+	orphaned := []models.OrphanedInstance{}
+	for _, obj := range objs {
+		if len(obj.GetOwnerReferences()) > 0 {
+			continue
+		}
+
+		lastUpdate := lastStatusUpdate(obj)
+		lastActivity := lastUpdate
+		if lastActivity.IsZero() {
+			// Without any status, the instance has been waiting for a controller since it was created.
+			lastActivity = obj.GetCreationTimestamp().Time
+		}
+		if now.Sub(lastActivity) < staleAfter {
+			continue
+		}
+
+		reasons := []string{"no ownerReferences"}
+		_, hasStatus := obj.Object["status"]
+		switch {
+		case !hasStatus:
+			reasons = append(reasons, fmt.Sprintf("no status since it was created %s ago", HumanReadableAge(lastActivity)))
+		case lastUpdate.IsZero():
+			// The status carries no timestamps, so only the creation time is known.
+			reasons = append(reasons, fmt.Sprintf("status has no update timestamps, created %s ago", HumanReadableAge(lastActivity)))
+		default:
+			reasons = append(reasons, fmt.Sprintf("status last updated %s ago", HumanReadableAge(lastActivity)))
+		}
+		if observed, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration"); found && observed < obj.GetGeneration() {
+			reasons = append(reasons, fmt.Sprintf("observedGeneration %d is behind generation %d", observed, obj.GetGeneration()))
+		}
+
+		orphaned = append(orphaned, models.OrphanedInstance{
+			InstanceSummary:  SummarizeInstance(obj),
+			LastStatusUpdate: Timestamp(lastUpdate),
+			Reasons:          reasons,
+		})
+	}
+	return orphaned
+}
+
+// lastStatusUpdate returns the latest time the status of obj was written, judging by the
+// timestamps of its conditions and by the managed fields of the status subresource. It is
+// the zero time when neither tells.
+func lastStatusUpdate(obj unstructured.Unstructured) time.Time {
+	var latest time.Time
+	observe := func(value string) {
+		if t, err := time.Parse(time.RFC3339, value); err == nil && t.After(latest) {
+			latest = t
+		}
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, field := range conditionTimeFields {
+			if value, _, _ := unstructured.NestedString(cond, field); value != "" {
+				observe(value)
+			}
+		}
+	}
+
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Subresource == "status" && entry.Time != nil && entry.Time.After(latest) {
+			latest = entry.Time.Time
+		}
+	}
+	return latest
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ErrInvalidPatch is returned when a patch cannot be decoded or applied to a resource.
+var ErrInvalidPatch = errors.New("invalid patch")
+
+// PreviewCRPatch applies a JSON patch or JSON merge patch to a custom resource locally
+// and returns the resource before and after the patch. Nothing is sent to the cluster
+// apart from reading the resource.
+func (c *Client) PreviewCRPatch(ctx context.Context, crdName, namespace, name string, patchType types.PatchType, patch []byte) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+	original, err := c.GetSingleCR(ctx, crdName, namespace, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	originalJSON, err := original.MarshalJSON()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+
+	var patchedJSON []byte
+	switch patchType {
+	case types.JSONPatchType:
+		decoded, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+		}
+		patchedJSON, err = decoded.Apply(originalJSON)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+		}
+	case types.MergePatchType:
+		patchedJSON, err = jsonpatch.MergePatch(originalJSON, patch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+		}
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported patch type %s", ErrInvalidPatch, patchType)
+	}
+
+	patched := &unstructured.Unstructured{}
+	if err := json.Unmarshal(patchedJSON, &patched.Object); err != nil {
+		return nil, nil, fmt.Errorf("%w: patched object is not valid: %w", ErrInvalidPatch, err)
+	}
+	return original, patched, nil
+}
+
+// DryRunCRPatch sends the patch to the API server as a server-side dry-run, which
+// validates it against the CRD schema and admission without persisting anything.
+// It returns the object as the API server would store it.
+func (c *Client) DryRunCRPatch(ctx context.Context, crdName, namespace, name string, patchType types.PatchType, patch []byte) (*unstructured.Unstructured, error) {
+	resource, err := c.crResource(ctx, crdName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := resource.Patch(ctx, name, patchType, patch, metav1.PatchOptions{
+		DryRun:          []string{metav1.DryRunAll},
+		FieldValidation: "Strict",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dry-run failed: %w", err)
+	}
+	unstructured.RemoveNestedField(patched.Object, "metadata", "managedFields")
+	return patched, nil
+}
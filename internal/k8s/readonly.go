@@ -0,0 +1,54 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package k8s
+
+import (
+	"errors"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pehlicd/crd-wizard/internal/logger"
+)
+
+// ErrReadOnly is returned for any mutating request made while the client is in read-only mode.
+var ErrReadOnly = errors.New("read-only mode: mutating requests are disabled")
+
+// readOnlyTransport rejects every request that could change cluster state.
+// Server-side dry-runs are let through since they never persist anything.
+type readOnlyTransport struct {
+	next http.RoundTripper
+	log  *logger.Logger
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.next.RoundTrip(req)
+	}
+	if req.URL.Query().Get("dryRun") == metav1.DryRunAll {
+		return t.next.RoundTrip(req)
+	}
+
+	t.log.Warn("blocked mutating request in read-only mode", "method", req.Method, "path", req.URL.Path)
+	return nil, ErrReadOnly
+}
+
+// ReadOnly reports whether the client refuses mutating requests.
+func (c *Client) ReadOnly() bool {
+	return c.opts.ReadOnly
+}
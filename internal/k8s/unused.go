@@ -0,0 +1,88 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"golang.org/x/sync/errgroup"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pehlicd/crd-wizard/internal/models"
+)
+
+// defaultUnusedConcurrency bounds how many CRDs UnusedCRDs checks at once when no limit is given.
+const defaultUnusedConcurrency = 10
+
+// UnusedCRDs checks every CRD of the cluster for instances and reports the ones without any,
+// checking at most concurrency CRDs at once. Objects of all versions are stored in the storage
+// version, so listing that one version covers them all. CRDs whose instances cannot be listed
+// are reported as uncounted rather than unused, as they may well be in use.
+func (c *Client) UnusedCRDs(ctx context.Context, concurrency int) (models.UnusedCRDReport, error) {
+	crds, err := c.ListCRDs(ctx, metav1.ListOptions{})
+	if err != nil {
+		return models.UnusedCRDReport{}, fmt.Errorf("failed to fetch CRDs: %w", err)
+	}
+	if concurrency < 1 {
+		concurrency = defaultUnusedConcurrency
+	}
+
+	used := make([]bool, len(crds))
+	errs := make([]error, len(crds))
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for i, crd := range crds {
+		g.Go(func() error {
+			used[i], errs[i] = c.hasInstances(ctx, crd)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	report := models.UnusedCRDReport{Checked: len(crds), Unused: []models.CRD{}}
+	for i, crd := range crds {
+		switch {
+		case errs[i] != nil:
+			report.Uncounted = append(report.Uncounted, models.UncountedCRD{Name: crd.Name, Error: errs[i].Error()})
+		case !used[i]:
+			report.Unused = append(report.Unused, models.FromK8sCRD(crd, 0))
+		}
+	}
+	sort.Slice(report.Unused, func(i, j int) bool { return report.Unused[i].Name < report.Unused[j].Name })
+	sort.Slice(report.Uncounted, func(i, j int) bool { return report.Uncounted[i].Name < report.Uncounted[j].Name })
+	return report, nil
+}
+
+// hasInstances reports whether at least one instance of crd exists, listing a single object
+// instead of all of them.
+func (c *Client) hasInstances(ctx context.Context, crd apiextensionsv1.CustomResourceDefinition) (bool, error) {
+	gvr, _ := getGVRFromCRD(crd)
+	if gvr.Resource == "" {
+		return false, fmt.Errorf("could not determine GVR for CRD %s", crd.Name)
+	}
+	list, err := c.DynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{
+		Limit:          1,
+		TimeoutSeconds: timeoutSeconds(c.opts.InstanceCountTimeout),
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(list.Items) > 0, nil
+}
@@ -18,7 +18,12 @@ package k8s
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pehlicd/crd-wizard/internal/models"
 )
 
 func HumanReadableAge(t time.Time) string {
@@ -43,3 +48,96 @@ func HumanReadableAge(t time.Time) string {
 	}
 	return fmt.Sprintf("%.0fs", d.Seconds())
 }
+
+// Timestamp formats t as RFC3339 in UTC, returning an empty string for the zero time.
+// It accompanies HumanReadableAge so clients can show the exact time alongside the age.
+func Timestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// readinessConditionTypes lists the condition types that best describe the overall
+// health of a resource, in order of preference.
+var readinessConditionTypes = []string{"Ready", "Available"}
+
+// InstanceStatus derives a short human-readable status for a custom resource.
+// It prefers a Ready/Available condition, then status.phase, and finally the
+// most recent (last) condition.
+func InstanceStatus(obj unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+
+	for _, condType := range readinessConditionTypes {
+		for _, c := range conditions {
+			cond, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			if t, _, _ := unstructured.NestedString(cond, "type"); t == condType {
+				return conditionStatus(cond)
+			}
+		}
+	}
+
+	if phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase"); phase != "" {
+		return phase
+	}
+
+	if len(conditions) > 0 {
+		if cond, ok := conditions[len(conditions)-1].(map[string]any); ok {
+			if reason, _, _ := unstructured.NestedString(cond, "reason"); reason != "" {
+				return reason
+			}
+			if t, _, _ := unstructured.NestedString(cond, "type"); t != "" {
+				return conditionStatus(cond)
+			}
+		}
+	}
+
+	return "Unknown"
+}
+
+// conditionStatus renders a condition as e.g. "Ready", "NotReady: Reason" or "Unknown: Reason".
+func conditionStatus(cond map[string]any) string {
+	condType, _, _ := unstructured.NestedString(cond, "type")
+	status, _, _ := unstructured.NestedString(cond, "status")
+	reason, _, _ := unstructured.NestedString(cond, "reason")
+
+	var result string
+	switch status {
+	case "True":
+		return condType
+	case "False":
+		result = "Not" + condType
+	default:
+		result = "Unknown"
+	}
+	if reason != "" {
+		result += ": " + reason
+	}
+	return result
+}
+
+// InstanceStatusStats counts the instances by the status InstanceStatus derives for
+// them. Reasons are dropped so that e.g. all "NotReady: ..." statuses share a bucket.
+func InstanceStatusStats(objs []unstructured.Unstructured) models.InstanceStats {
+	stats := models.InstanceStats{Total: len(objs), ByStatus: make(map[string]int)}
+	for _, obj := range objs {
+		status, _, _ := strings.Cut(InstanceStatus(obj), ": ")
+		stats.ByStatus[status]++
+	}
+	return stats
+}
+
+// SummarizeInstance builds the compact list representation of a custom resource.
+func SummarizeInstance(obj unstructured.Unstructured) models.InstanceSummary {
+	return models.InstanceSummary{
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		Status:    InstanceStatus(obj),
+		Age:       HumanReadableAge(obj.GetCreationTimestamp().Time),
+		CreatedAt: Timestamp(obj.GetCreationTimestamp().Time),
+		UID:       string(obj.GetUID()),
+	}
+}
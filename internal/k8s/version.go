@@ -0,0 +1,45 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package k8s
+
+import (
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+)
+
+var (
+	// crdV1Version is the first release serving apiextensions.k8s.io/v1, older clusters fall back to v1beta1.
+	crdV1Version = utilversion.MajorMinor(1, 16)
+	// crdV1beta1RemovedVersion is the first release that no longer serves apiextensions.k8s.io/v1beta1.
+	crdV1beta1RemovedVersion = utilversion.MajorMinor(1, 22)
+)
+
+// versionWarnings returns the limitations of the tool on a cluster running gitVersion.
+// Versions that cannot be parsed produce no warnings.
+func versionWarnings(gitVersion string) []string {
+	v, err := utilversion.ParseGeneric(gitVersion)
+	if err != nil {
+		return nil
+	}
+
+	switch {
+	case v.LessThan(crdV1Version):
+		return []string{"Kubernetes " + gitVersion + " does not serve apiextensions.k8s.io/v1, CRDs are read through v1beta1 and converted. Schemas may not be structural, so schema views and generated docs can be incomplete."}
+	case v.LessThan(crdV1beta1RemovedVersion):
+		return []string{"Kubernetes " + gitVersion + " still serves apiextensions.k8s.io/v1beta1. CRDs created through it are listed, but may lack a structural schema, so their schema and generated docs can be incomplete."}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CREventType is the kind of change reported by WatchCRs.
+type CREventType string
+
+const (
+	CRAdded    CREventType = "ADDED"
+	CRModified CREventType = "MODIFIED"
+	CRDeleted  CREventType = "DELETED"
+)
+
+// CREvent is a change to an instance of a watched CRD.
+type CREvent struct {
+	Type   CREventType
+	Object *unstructured.Unstructured
+}
+
+// WatchCRs calls handler for every instance of the CRD that is added, modified or deleted
+// until ctx is done. Instances that already exist when the watch starts are not reported.
+// handler is called from a single goroutine and should return quickly.
+func (c *Client) WatchCRs(ctx context.Context, crdName string, handler func(CREvent)) error {
+	crd, err := c.getCRD(ctx, crdName)
+	if err != nil {
+		return fmt.Errorf("failed to get CRD %s: %w", crdName, err)
+	}
+	gvr, _ := getGVRFromCRD(*crd)
+	if gvr.Resource == "" {
+		return fmt.Errorf("could not determine GVR for CRD %s", crdName)
+	}
+
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(c.DynamicClient, 0)
+	informer := factory.ForResource(gvr).Informer()
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj any, isInInitialList bool) {
+			if u, ok := obj.(*unstructured.Unstructured); ok && !isInInitialList {
+				handler(CREvent{Type: CRAdded, Object: u})
+			}
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			oldU, _ := oldObj.(*unstructured.Unstructured)
+			newU, ok := newObj.(*unstructured.Unstructured)
+			// Re-lists after a dropped watch deliver unchanged objects as updates.
+			if !ok || (oldU != nil && oldU.GetResourceVersion() == newU.GetResourceVersion()) {
+				return
+			}
+			handler(CREvent{Type: CRModified, Object: newU})
+		},
+		DeleteFunc: func(obj any) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if u, ok := obj.(*unstructured.Unstructured); ok {
+				handler(CREvent{Type: CRDeleted, Object: u})
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", crdName, err)
+	}
+
+	factory.Start(ctx.Done())
+	c.log.Debug("started watching custom resources", "crd", crdName, "cluster", c.ClusterName)
+	return nil
+}
@@ -0,0 +1,187 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// QualifiedName returns namespace/name for namespaced resources and the name otherwise.
+func (n Node) QualifiedName() string {
+	if n.Namespace == "" {
+		return n.Label
+	}
+	return n.Namespace + "/" + n.Label
+}
+
+// Tree renders the graph as an ASCII tree, starting from every node that is not the
+// target of an edge. label renders a single node. Edges leading back to a node on the
+// current branch are marked with "(cycle)" instead of being followed.
+func (g *ResourceGraph) Tree(label func(Node) string) string {
+	nodes, adj, roots := g.index()
+
+	var b strings.Builder
+	rendered := make(map[string]bool, len(nodes))
+	for _, rootID := range roots {
+		writeTree(&b, rootID, "", true, nodes, adj, label, map[string]bool{}, rendered)
+	}
+	// Nodes that are only reachable through a cycle have no root, so start from them directly.
+	for _, id := range g.sortedIDs(nodes) {
+		if !rendered[id] {
+			writeTree(&b, id, "", true, nodes, adj, label, map[string]bool{}, rendered)
+		}
+	}
+	return b.String()
+}
+
+func writeTree(b *strings.Builder, nodeID, prefix string, isLast bool, nodes map[string]Node, adj map[string][]string, label func(Node) string, ancestors, rendered map[string]bool) {
+	node, ok := nodes[nodeID]
+	if !ok {
+		return
+	}
+
+	b.WriteString(prefix)
+	if isLast {
+		b.WriteString("└── ")
+		prefix += "    "
+	} else {
+		b.WriteString("├── ")
+		prefix += "│   "
+	}
+	b.WriteString(label(node))
+	if ancestors[nodeID] {
+		b.WriteString(" (cycle)\n")
+		return
+	}
+	b.WriteString("\n")
+	rendered[nodeID] = true
+
+	ancestors[nodeID] = true
+	defer delete(ancestors, nodeID)
+	children := adj[nodeID]
+	for i, childID := range children {
+		writeTree(b, childID, prefix, i == len(children)-1, nodes, adj, label, ancestors, rendered)
+	}
+}
+
+// index returns the nodes by ID, the children of every node and the root nodes,
+// ordered by kind and name so that renderings are stable.
+func (g *ResourceGraph) index() (map[string]Node, map[string][]string, []string) {
+	nodes := make(map[string]Node, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes[n.ID] = n
+	}
+	less := func(ids []string) func(i, j int) bool {
+		return func(i, j int) bool { return nodeLess(nodes[ids[i]], nodes[ids[j]]) }
+	}
+
+	adj := make(map[string][]string)
+	isTarget := make(map[string]bool)
+	for _, e := range g.Edges {
+		adj[e.Source] = append(adj[e.Source], e.Target)
+		isTarget[e.Target] = true
+	}
+	for _, children := range adj {
+		sort.SliceStable(children, less(children))
+	}
+
+	var roots []string
+	for _, n := range g.Nodes {
+		if !isTarget[n.ID] {
+			roots = append(roots, n.ID)
+		}
+	}
+	sort.SliceStable(roots, less(roots))
+	return nodes, adj, roots
+}
+
+// DOT renders the graph in the Graphviz DOT language.
+func (g *ResourceGraph) DOT() string {
+	nodes, _, _ := g.index()
+
+	var b strings.Builder
+	b.WriteString("digraph resources {\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, id := range g.sortedIDs(nodes) {
+		n := nodes[id]
+		b.WriteString(fmt.Sprintf("  %q [label=%q];\n", n.ID, n.Type+"\n"+n.QualifiedName()))
+	}
+	for _, e := range g.sortedEdges() {
+		b.WriteString(fmt.Sprintf("  %q -> %q;\n", e.Source, e.Target))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart.
+func (g *ResourceGraph) Mermaid() string {
+	nodes, _, _ := g.index()
+
+	// Node IDs are UIDs, which Mermaid does not accept as identifiers.
+	ids := make(map[string]string, len(nodes))
+	var b strings.Builder
+	b.WriteString("graph TD\n")
+	for i, id := range g.sortedIDs(nodes) {
+		n := nodes[id]
+		ids[id] = fmt.Sprintf("n%d", i)
+		label := strings.ReplaceAll(n.Type+": "+n.QualifiedName(), `"`, "#quot;")
+		b.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", ids[id], label))
+	}
+	for _, e := range g.sortedEdges() {
+		source, okSource := ids[e.Source]
+		target, okTarget := ids[e.Target]
+		if okSource && okTarget {
+			b.WriteString(fmt.Sprintf("  %s --> %s\n", source, target))
+		}
+	}
+	return b.String()
+}
+
+// sortedIDs returns the node IDs ordered by kind and name.
+func (g *ResourceGraph) sortedIDs(nodes map[string]Node) []string {
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return nodeLess(nodes[ids[i]], nodes[ids[j]]) })
+	return ids
+}
+
+// nodeLess orders nodes by kind, then name, then ID.
+func nodeLess(a, b Node) bool {
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+	if a.QualifiedName() != b.QualifiedName() {
+		return a.QualifiedName() < b.QualifiedName()
+	}
+	return a.ID < b.ID
+}
+
+// sortedEdges returns the edges ordered by source and target.
+func (g *ResourceGraph) sortedEdges() []Edge {
+	edges := append([]Edge(nil), g.Edges...)
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Source != edges[j].Source {
+			return edges[i].Source < edges[j].Source
+		}
+		return edges[i].Target < edges[j].Target
+	})
+	return edges
+}
@@ -0,0 +1,338 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package models
+
+import (
+	"bytes"
+	"html/template"
+	"sort"
+)
+
+// htmlNode is a node as the script of the HTML graph page consumes it.
+type htmlNode struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// APIVersion is shown in the details of a selected node.
+	APIVersion string `json:"apiVersion,omitempty"`
+	Color      string `json:"color"`
+	Marked     bool   `json:"marked,omitempty"`
+}
+
+// htmlGraph is the data the HTML graph page is rendered from.
+type htmlGraph struct {
+	Title string
+	Nodes []htmlNode
+	Edges []Edge
+	// Kinds lists every kind of the graph once with its color, for the legend.
+	Kinds     []htmlNode
+	Truncated bool
+}
+
+// HTML renders the graph as a self-contained HTML page that draws it as an interactive diagram,
+// with nodes colored by kind. The node with the given ID is highlighted.
+func (g *ResourceGraph) HTML(title, markedID string) (string, error) {
+	nodes, _, _ := g.index()
+
+	data := htmlGraph{Title: title, Edges: g.sortedEdges(), Truncated: g.Truncated}
+	seenKinds := make(map[string]bool)
+	for _, id := range g.sortedIDs(nodes) {
+		n := nodes[id]
+		node := htmlNode{
+			ID:         n.ID,
+			Kind:       n.Type,
+			Name:       n.Label,
+			Namespace:  n.Namespace,
+			APIVersion: n.APIVersion,
+			Color:      KindColor(n.Type),
+			Marked:     n.ID == markedID,
+		}
+		data.Nodes = append(data.Nodes, node)
+		if !seenKinds[n.Type] {
+			seenKinds[n.Type] = true
+			data.Kinds = append(data.Kinds, htmlNode{Kind: n.Type, Color: node.Color})
+		}
+	}
+	sort.Slice(data.Kinds, func(i, j int) bool { return data.Kinds[i].Kind < data.Kinds[j].Kind })
+
+	tmpl, err := template.New("graph").Parse(graphHTMLTemplate)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// KindColor returns the color of a Kubernetes resource kind in graph renderings, so that the
+// TUI and exported graphs look alike.
+func KindColor(kind string) string {
+	switch kind {
+	// Workload Resources
+	case "Pod":
+		return "#0EA5E9" // sky
+	case "Deployment":
+		return "#10B981" // emerald
+	case "StatefulSet":
+		return "#F59E0B" // amber
+	case "DaemonSet":
+		return "#14B8A6" // teal
+	case "Job":
+		return "#8B5CF6" // violet
+	case "CronJob":
+		return "#D946EF" // fuchsia
+	case "ReplicaSet":
+		return "#06B6D4" // cyan
+	case "ReplicationController":
+		return "#3B82F6" // blue
+
+	// Service Discovery & Load Balancing
+	case "Service":
+		return "#F97316" // orange
+	case "Ingress":
+		return "#6366F1" // indigo
+	case "Endpoint", "EndpointSlice":
+		return "#EC4899" // pink
+
+	// Configuration & Storage
+	case "ConfigMap":
+		return "#84CC16" // lime
+	case "Secret":
+		return "#EF4444" // red
+	case "PersistentVolume":
+		return "#EAB308" // yellow
+	case "PersistentVolumeClaim":
+		return "#22C55E" // green
+	case "StorageClass":
+		return "#A855F7" // purple
+
+	// Security & RBAC
+	case "ServiceAccount":
+		return "#71717A" // zinc
+	case "Role", "ClusterRole":
+		return "#38BDF8" // sky
+	case "RoleBinding", "ClusterRoleBinding":
+		return "#FB923C" // orange
+
+	// Policy Resources
+	case "NetworkPolicy":
+		return "#22D3EE" // cyan
+	case "PodDisruptionBudget":
+		return "#34D399" // emerald
+
+	// Custom Resources
+	case "CustomResourceDefinition":
+		return "#818CF8" // indigo
+
+	default:
+		return "#FFFFFF" // Default to white
+	}
+}
+
+// graphHTMLTemplate draws the graph as SVG in layers from the owners down to the resources they
+// own. The script is inlined so that the page works offline and can be shared as a single file.
+const graphHTMLTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Title }}</title>
+<style>
+  html, body { margin: 0; height: 100%; background: #111827; color: #E5E7EB; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; }
+  header { position: fixed; top: 0; left: 0; right: 0; padding: 12px 16px; background: rgba(17, 24, 39, 0.9); border-bottom: 1px solid #374151; z-index: 1; }
+  header h1 { margin: 0; font-size: 16px; }
+  header p { margin: 4px 0 0; font-size: 12px; color: #9CA3AF; }
+  header p.warning { color: #F59E0B; }
+  #legend { display: flex; flex-wrap: wrap; gap: 12px; margin-top: 8px; font-size: 12px; }
+  #legend span::before { content: ""; display: inline-block; width: 10px; height: 10px; margin-right: 4px; border-radius: 2px; background: var(--color); }
+  #details { position: fixed; right: 16px; bottom: 16px; min-width: 240px; padding: 12px; background: #1F2937; border: 1px solid #374151; border-radius: 6px; font-size: 13px; display: none; }
+  #details dt { color: #9CA3AF; font-size: 11px; text-transform: uppercase; }
+  #details dd { margin: 0 0 8px; word-break: break-all; }
+  svg { width: 100%; height: 100%; cursor: grab; }
+  svg.dragging { cursor: grabbing; }
+  .node { cursor: pointer; }
+  .node rect { fill: #1F2937; stroke-width: 2; }
+  .node.marked rect { stroke-width: 4; }
+  .node text { fill: #E5E7EB; font-size: 12px; }
+  .node text.kind { font-weight: bold; }
+  .edge { stroke: #6B7280; stroke-width: 1.5; fill: none; }
+  .dimmed { opacity: 0.25; }
+  .edge.active { stroke: #E5E7EB; }
+</style>
+</head>
+<body>
+<header>
+  <h1>{{ .Title }}</h1>
+  <p>Drag to pan, scroll to zoom, click a resource to show its details and relations.</p>
+  {{ if .Truncated }}<p class="warning">The cluster scan hit its object limit, so this graph may be partial.</p>{{ end }}
+  <div id="legend">{{ range .Kinds }}<span style="--color: {{ .Color }}">{{ .Kind }}</span>{{ end }}</div>
+</header>
+<svg id="graph" xmlns="http://www.w3.org/2000/svg">
+  <defs>
+    <marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto-start-reverse">
+      <path d="M 0 0 L 10 5 L 0 10 z" fill="#6B7280"></path>
+    </marker>
+  </defs>
+  <g id="viewport"></g>
+</svg>
+<dl id="details"></dl>
+<script>
+(function () {
+  var nodes = {{ .Nodes }} || [];
+  var edges = {{ .Edges }} || [];
+  var NODE_WIDTH = 220, NODE_HEIGHT = 44, GAP_X = 30, GAP_Y = 80, SVG_NS = "http://www.w3.org/2000/svg";
+
+  var byId = {};
+  nodes.forEach(function (n) { byId[n.id] = n; n.children = []; n.parents = []; });
+  edges = edges.filter(function (e) { return byId[e.source] && byId[e.target]; });
+  edges.forEach(function (e) { byId[e.source].children.push(e.target); byId[e.target].parents.push(e.source); });
+
+  // Place every node one layer below its deepest owner. Relaxing at most once per node
+  // keeps cycles from looping forever.
+  nodes.forEach(function (n) { n.layer = 0; });
+  for (var i = 0; i < nodes.length; i++) {
+    var changed = false;
+    edges.forEach(function (e) {
+      if (byId[e.target].layer < byId[e.source].layer + 1) {
+        byId[e.target].layer = byId[e.source].layer + 1;
+        changed = true;
+      }
+    });
+    if (!changed) break;
+  }
+
+  var layers = [];
+  nodes.forEach(function (n) { (layers[n.layer] = layers[n.layer] || []).push(n); });
+  var widest = 0;
+  layers.forEach(function (layer) { if (layer) widest = Math.max(widest, layer.length); });
+  layers.forEach(function (layer, depth) {
+    if (!layer) return;
+    var offset = (widest - layer.length) * (NODE_WIDTH + GAP_X) / 2;
+    layer.forEach(function (n, j) {
+      n.x = offset + j * (NODE_WIDTH + GAP_X);
+      n.y = depth * (NODE_HEIGHT + GAP_Y);
+    });
+  });
+
+  function el(name, attrs, parent) {
+    var e = document.createElementNS(SVG_NS, name);
+    Object.keys(attrs).forEach(function (k) { e.setAttribute(k, attrs[k]); });
+    parent.appendChild(e);
+    return e;
+  }
+  function truncate(text, max) {
+    return text.length > max ? text.slice(0, max - 1) + "…" : text;
+  }
+  function qualifiedName(n) {
+    return n.namespace ? n.namespace + "/" + n.name : n.name;
+  }
+
+  var svg = document.getElementById("graph");
+  var viewport = document.getElementById("viewport");
+  edges.forEach(function (e) {
+    var s = byId[e.source], t = byId[e.target];
+    var x1 = s.x + NODE_WIDTH / 2, y1 = s.y + NODE_HEIGHT, x2 = t.x + NODE_WIDTH / 2, y2 = t.y;
+    if (t.layer <= s.layer) {
+      // Edges of a cycle point upwards, route them around the side of the nodes.
+      x1 = s.x + NODE_WIDTH; y1 = s.y + NODE_HEIGHT / 2; x2 = t.x + NODE_WIDTH; y2 = t.y + NODE_HEIGHT / 2;
+      e.path = el("path", { "class": "edge", "marker-end": "url(#arrow)", d: "M" + x1 + "," + y1 + " C" + (x1 + 60) + "," + y1 + " " + (x2 + 60) + "," + y2 + " " + x2 + "," + y2 }, viewport);
+      return;
+    }
+    var mid = (y1 + y2) / 2;
+    e.path = el("path", { "class": "edge", "marker-end": "url(#arrow)", d: "M" + x1 + "," + y1 + " C" + x1 + "," + mid + " " + x2 + "," + mid + " " + x2 + "," + y2 }, viewport);
+  });
+  nodes.forEach(function (n) {
+    var g = el("g", { "class": "node" + (n.marked ? " marked" : ""), transform: "translate(" + n.x + "," + n.y + ")" }, viewport);
+    el("rect", { width: NODE_WIDTH, height: NODE_HEIGHT, rx: 6, stroke: n.color }, g);
+    el("text", { "class": "kind", x: 10, y: 18, fill: n.color }, g).textContent = truncate(n.kind, 30);
+    el("text", { x: 10, y: 35 }, g).textContent = truncate(qualifiedName(n), 32);
+    el("title", {}, g).textContent = n.kind + ": " + qualifiedName(n);
+    g.addEventListener("click", function (ev) { ev.stopPropagation(); select(n); });
+    n.element = g;
+  });
+
+  var details = document.getElementById("details");
+  function select(n) {
+    var related = {};
+    if (n) {
+      related[n.id] = true;
+      n.children.concat(n.parents).forEach(function (id) { related[id] = true; });
+    }
+    nodes.forEach(function (m) { m.element.classList.toggle("dimmed", !!n && !related[m.id]); });
+    edges.forEach(function (e) {
+      var active = !!n && (e.source === n.id || e.target === n.id);
+      e.path.classList.toggle("active", active);
+      e.path.classList.toggle("dimmed", !!n && !active);
+    });
+    if (!n) {
+      details.style.display = "none";
+      return;
+    }
+    details.innerHTML = "";
+    [["Kind", n.kind], ["Name", n.name], ["Namespace", n.namespace], ["API Version", n.apiVersion], ["UID", n.id],
+      ["Owners", n.parents.length], ["Owns", n.children.length]].forEach(function (row) {
+      if (row[1] === undefined || row[1] === "") return;
+      var dt = document.createElement("dt");
+      dt.textContent = row[0];
+      var dd = document.createElement("dd");
+      dd.textContent = row[1];
+      details.appendChild(dt);
+      details.appendChild(dd);
+    });
+    details.style.display = "block";
+  }
+  var dragged = false;
+  svg.addEventListener("click", function () {
+    // Releasing the mouse after panning is not a click on the background.
+    if (!dragged) select(null);
+  });
+
+  // Fit the graph below the header, then let the user pan and zoom.
+  var header = document.querySelector("header");
+  var width = Math.max(widest * (NODE_WIDTH + GAP_X), 1), height = Math.max(layers.length * (NODE_HEIGHT + GAP_Y), 1);
+  var scale = Math.min(1, (svg.clientWidth - 40) / width, (svg.clientHeight - header.offsetHeight - 40) / height);
+  var tx = (svg.clientWidth - width * scale) / 2, ty = header.offsetHeight + 20;
+  function apply() { viewport.setAttribute("transform", "translate(" + tx + "," + ty + ") scale(" + scale + ")"); }
+  apply();
+
+  var drag = null;
+  svg.addEventListener("mousedown", function (ev) { drag = { x: ev.clientX - tx, y: ev.clientY - ty }; dragged = false; });
+  window.addEventListener("mousemove", function (ev) {
+    if (!drag) return;
+    tx = ev.clientX - drag.x;
+    ty = ev.clientY - drag.y;
+    dragged = true;
+    svg.classList.add("dragging");
+    apply();
+  });
+  window.addEventListener("mouseup", function () { drag = null; svg.classList.remove("dragging"); });
+  svg.addEventListener("wheel", function (ev) {
+    ev.preventDefault();
+    var factor = ev.deltaY < 0 ? 1.1 : 1 / 1.1;
+    tx = ev.clientX - (ev.clientX - tx) * factor;
+    ty = ev.clientY - (ev.clientY - ty) * factor;
+    scale *= factor;
+    apply();
+  }, { passive: false });
+})();
+</script>
+</body>
+</html>
+`
@@ -23,31 +23,40 @@ import (
 
 // APICRD is used for the web API, to include the full spec.
 type APICRD struct {
-	APIVersion    string                                       `json:"apiVersion"`
-	Kind          string                                       `json:"kind"`
-	Metadata      metav1.ObjectMeta                            `json:"metadata"`
-	Spec          apiextensionsv1.CustomResourceDefinitionSpec `json:"spec"`
-	InstanceCount int                                          `json:"instanceCount"`
+	APIVersion           string                                       `json:"apiVersion"`
+	Kind                 string                                       `json:"kind"`
+	Metadata             metav1.ObjectMeta                            `json:"metadata"`
+	Spec                 apiextensionsv1.CustomResourceDefinitionSpec `json:"spec"`
+	InstanceCount        int                                          `json:"instanceCount"`
+	HasConversionWebhook bool                                         `json:"hasConversionWebhook"`
+	ShortNames           []string                                     `json:"shortNames,omitempty"`
+	Categories           []string                                     `json:"categories,omitempty"`
 }
 
 // CRD model is used for the TUI, which only needs a subset of fields.
 type CRD struct {
-	APIVersion    string `json:"apiVersion"`
-	Kind          string `json:"kind"`
-	Name          string `json:"name"`
-	Group         string `json:"group"`
-	Scope         string `json:"scope"`
-	InstanceCount int    `json:"instanceCount"`
+	APIVersion           string   `json:"apiVersion"`
+	Kind                 string   `json:"kind"`
+	Name                 string   `json:"name"`
+	Group                string   `json:"group"`
+	Scope                string   `json:"scope"`
+	InstanceCount        int      `json:"instanceCount"`
+	HasConversionWebhook bool     `json:"hasConversionWebhook"`
+	ShortNames           []string `json:"shortNames,omitempty"`
+	Categories           []string `json:"categories,omitempty"`
 }
 
 func FromK8sCRD(k8sCrd apiextensionsv1.CustomResourceDefinition, instanceCount int) CRD {
 	return CRD{
-		APIVersion:    k8sCrd.APIVersion,
-		Kind:          k8sCrd.Spec.Names.Kind,
-		Name:          k8sCrd.Name,
-		Group:         k8sCrd.Spec.Group,
-		Scope:         string(k8sCrd.Spec.Scope),
-		InstanceCount: instanceCount,
+		APIVersion:           k8sCrd.APIVersion,
+		Kind:                 k8sCrd.Spec.Names.Kind,
+		Name:                 k8sCrd.Name,
+		Group:                k8sCrd.Spec.Group,
+		Scope:                string(k8sCrd.Spec.Scope),
+		InstanceCount:        instanceCount,
+		HasConversionWebhook: hasConversionWebhook(k8sCrd.Spec),
+		ShortNames:           k8sCrd.Spec.Names.ShortNames,
+		Categories:           k8sCrd.Spec.Names.Categories,
 	}
 }
 
@@ -55,18 +64,97 @@ func ToAPICRD(k8sCrd apiextensionsv1.CustomResourceDefinition, instanceCount int
 	metadata := k8sCrd.ObjectMeta
 	metadata.ManagedFields = nil
 	return APICRD{
-		APIVersion:    k8sCrd.APIVersion,
-		Kind:          k8sCrd.Kind,
-		Metadata:      metadata,
-		Spec:          k8sCrd.Spec,
-		InstanceCount: instanceCount,
+		APIVersion:           k8sCrd.APIVersion,
+		Kind:                 k8sCrd.Kind,
+		Metadata:             metadata,
+		Spec:                 k8sCrd.Spec,
+		InstanceCount:        instanceCount,
+		HasConversionWebhook: hasConversionWebhook(k8sCrd.Spec),
+		ShortNames:           k8sCrd.Spec.Names.ShortNames,
+		Categories:           k8sCrd.Spec.Names.Categories,
 	}
 }
 
+// hasConversionWebhook reports whether objects of the CRD are converted between
+// versions by an external webhook rather than by the API server itself.
+func hasConversionWebhook(spec apiextensionsv1.CustomResourceDefinitionSpec) bool {
+	return spec.Conversion != nil && spec.Conversion.Strategy == apiextensionsv1.WebhookConverter
+}
+
+// CRDVersion describes the state of a single version of a CRD.
+type CRDVersion struct {
+	Name               string `json:"name"`
+	Served             bool   `json:"served"`
+	Storage            bool   `json:"storage"`
+	Deprecated         bool   `json:"deprecated"`
+	DeprecationWarning string `json:"deprecationWarning,omitempty"`
+}
+
+// CRDVersions returns the versions of the CRD in the order they are declared.
+func CRDVersions(k8sCrd apiextensionsv1.CustomResourceDefinition) []CRDVersion {
+	versions := make([]CRDVersion, 0, len(k8sCrd.Spec.Versions))
+	for _, v := range k8sCrd.Spec.Versions {
+		version := CRDVersion{
+			Name:       v.Name,
+			Served:     v.Served,
+			Storage:    v.Storage,
+			Deprecated: v.Deprecated,
+		}
+		if v.DeprecationWarning != nil {
+			version.DeprecationWarning = *v.DeprecationWarning
+		}
+		versions = append(versions, version)
+	}
+	return versions
+}
+
+// InstanceSummary is a compact view of a custom resource for list views.
+type InstanceSummary struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+	Age       string `json:"age"`
+	CreatedAt string `json:"createdAt"`
+	UID       string `json:"uid"`
+}
+
+// UnusedCRDReport lists the CRDs of a cluster that have no instances.
+type UnusedCRDReport struct {
+	// Checked is the number of CRDs in the cluster.
+	Checked int   `json:"checked"`
+	Unused  []CRD `json:"unused"`
+	// Uncounted lists the CRDs whose instances could not be listed, which may be in use.
+	Uncounted []UncountedCRD `json:"uncounted,omitempty"`
+}
+
+// UncountedCRD is a CRD whose instances could not be listed, with the reason why.
+type UncountedCRD struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// OrphanedInstance is a custom resource that looks left behind by an absent controller.
+type OrphanedInstance struct {
+	InstanceSummary
+	// LastStatusUpdate is when the status was last written, empty when that is unknown.
+	LastStatusUpdate string `json:"lastStatusUpdate,omitempty"`
+	// Reasons explain why the instance was flagged.
+	Reasons []string `json:"reasons"`
+}
+
+// InstanceStats counts the instances of a CRD by their derived status.
+type InstanceStats struct {
+	Total int `json:"total"`
+	// ByStatus is keyed by status without its reason, e.g. "Ready" or "NotReady".
+	ByStatus map[string]int `json:"byStatus"`
+}
+
 // ResourceGraph represents the structure for the graph API response.
 type ResourceGraph struct {
 	Nodes []Node `json:"nodes"`
 	Edges []Edge `json:"edges"`
+	// Truncated is set when the cluster scan hit its object limit, so the graph may be partial.
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // Node represents a single Kubernetes resource in the graph.
@@ -74,6 +162,9 @@ type Node struct {
 	ID    string `json:"id"`
 	Label string `json:"label"`
 	Type  string `json:"type"`
+	// Namespace is empty for cluster-scoped resources.
+	Namespace  string `json:"namespace,omitempty"`
+	APIVersion string `json:"apiVersion,omitempty"`
 }
 
 // Edge represents a relationship between two nodes in the graph.
@@ -87,6 +178,18 @@ type ClusterInfo struct {
 	ClusterName   string `json:"clusterName"`
 	ServerVersion string `json:"serverVersion"`
 	NumCRDs       int    `json:"numCRDs"`
+	// Warnings describe limitations of the tool on this cluster, e.g. due to its version.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// BuildInfo identifies the build of crd-wizard, as set at build time.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	BuildDate string `json:"buildDate"`
+	Commit    string `json:"commit"`
+	GoVersion string `json:"goVersion"`
+	// Platform is the operating system and architecture, e.g. linux/amd64.
+	Platform string `json:"platform"`
 }
 
 type Status int
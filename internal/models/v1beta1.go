@@ -0,0 +1,47 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package models
+
+import (
+	"fmt"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+)
+
+// FromV1beta1CRD converts a CRD of the deprecated apiextensions.k8s.io/v1beta1 API into its
+// v1 form, the way the API server does. The global spec.validation, subresources and printer
+// columns of v1beta1 are copied into every version, so code reading per-version schemas
+// works unchanged. The original apiVersion and kind are kept.
+func FromV1beta1CRD(in apiextensionsv1beta1.CustomResourceDefinition) (apiextensionsv1.CustomResourceDefinition, error) {
+	// Objects read from files are not defaulted by an API server, e.g. spec.versions may be
+	// missing in favour of the deprecated spec.version.
+	crd := in.DeepCopy()
+	apiextensionsv1beta1.SetObjectDefaults_CustomResourceDefinition(crd)
+
+	var internal apiextensions.CustomResourceDefinition
+	if err := apiextensionsv1beta1.Convert_v1beta1_CustomResourceDefinition_To_apiextensions_CustomResourceDefinition(crd, &internal, nil); err != nil {
+		return apiextensionsv1.CustomResourceDefinition{}, fmt.Errorf("failed to convert v1beta1 CRD %s: %w", in.Name, err)
+	}
+	var out apiextensionsv1.CustomResourceDefinition
+	if err := apiextensionsv1.Convert_apiextensions_CustomResourceDefinition_To_v1_CustomResourceDefinition(&internal, &out, nil); err != nil {
+		return apiextensionsv1.CustomResourceDefinition{}, fmt.Errorf("failed to convert v1beta1 CRD %s: %w", in.Name, err)
+	}
+	out.TypeMeta = in.TypeMeta
+	return out, nil
+}
@@ -0,0 +1,333 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+// Package skeleton builds example manifests of custom resources from their OpenAPI schema.
+package skeleton
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/pehlicd/crd-wizard/internal/models"
+)
+
+// DefaultMaxDepth is how deep nested objects and arrays are expanded when Options.MaxDepth is not set.
+const DefaultMaxDepth = 10
+
+// optionalMarker tags the lines of optional fields while encoding, so that they can be
+// commented out afterwards. yaml.v3 cannot emit commented-out nodes itself.
+const optionalMarker = "crd-wizard:optional"
+
+// Options tunes the generated manifest.
+type Options struct {
+	// MaxDepth bounds how deep nested objects and arrays are expanded, DefaultMaxDepth when zero.
+	MaxDepth int
+	// MaxDescription cuts the description comments to the first sentence, truncated to this
+	// many characters. Zero keeps descriptions whole.
+	MaxDescription int
+	// Namespaced adds a namespace to the metadata of the manifest.
+	Namespaced bool
+}
+
+// FromCRD returns a skeleton manifest of the storage version of crd, falling back to the
+// first version when no storage version is marked. Namespaced is set from the scope of crd.
+func FromCRD(crd models.APICRD, opts Options) (string, error) {
+	if len(crd.Spec.Versions) == 0 {
+		return "", fmt.Errorf("CRD %s has no versions", crd.Metadata.Name)
+	}
+	version := crd.Spec.Versions[0]
+	for _, candidate := range crd.Spec.Versions {
+		if candidate.Storage {
+			version = candidate
+			break
+		}
+	}
+
+	schemaJSON := "{}"
+	if version.Schema != nil && version.Schema.OpenAPIV3Schema != nil {
+		b, err := json.Marshal(version.Schema.OpenAPIV3Schema)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal schema: %w", err)
+		}
+		schemaJSON = string(b)
+	}
+	opts.Namespaced = crd.Spec.Scope == apiextensionsv1.NamespaceScoped
+	return FromSchema(crd.Spec.Group, version.Name, crd.Spec.Names.Kind, schemaJSON, opts)
+}
+
+// FromSchema returns a skeleton manifest of kind from its OpenAPI schema in JSON. Required
+// fields are filled with type-appropriate placeholders, optional fields are commented out so
+// users can uncomment what they need, and every field is preceded by its description.
+func FromSchema(group, version, kind, schemaJSON string, opts Options) (string, error) {
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(schemaJSON), &schema); err != nil {
+		return "", fmt.Errorf("failed to unmarshal schema: %w", err)
+	}
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = DefaultMaxDepth
+	}
+	b := builder{opts: opts}
+
+	metadata := &yaml.Node{Kind: yaml.MappingNode}
+	metadata.Content = append(metadata.Content, keyNode("name"), stringNode("my-"+strings.ToLower(kind)))
+	if opts.Namespaced {
+		metadata.Content = append(metadata.Content, keyNode("namespace"), stringNode("default"))
+	}
+
+	apiVersion := version
+	if group != "" {
+		apiVersion = group + "/" + version
+	}
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	root.Content = append(root.Content,
+		keyNode("apiVersion"), stringNode(apiVersion),
+		keyNode("kind"), stringNode(kind),
+		keyNode("metadata"), metadata,
+	)
+
+	properties, _ := schema["properties"].(map[string]any)
+	fields := make(map[string]any, len(properties))
+	for name, prop := range properties {
+		// These are either written above or not part of the desired state.
+		if name == "apiVersion" || name == "kind" || name == "metadata" || name == "status" {
+			continue
+		}
+		fields[name] = prop
+	}
+	// spec is rarely marked as required but a resource without it is seldom useful.
+	required, _ := schema["required"].([]any)
+	b.fields(root, map[string]any{"properties": fields, "required": append(required, "spec")}, 0, false)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}); err != nil {
+		return "", fmt.Errorf("failed to marshal skeleton: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("failed to marshal skeleton: %w", err)
+	}
+	return commentOutOptional(buf.String()), nil
+}
+
+// builder turns schemas into YAML nodes.
+type builder struct {
+	opts Options
+}
+
+// fields appends the properties of an object schema to obj, required ones first. With all,
+// every field is treated as required unless the schema requires some, which is used for
+// array items so that their example element is not made of comments only.
+func (b *builder) fields(obj *yaml.Node, schema map[string]any, depth int, all bool) {
+	properties, _ := schema["properties"].(map[string]any)
+	required := make(map[string]bool)
+	if list, ok := schema["required"].([]any); ok {
+		for _, name := range list {
+			if name, ok := name.(string); ok && properties[name] != nil {
+				required[name] = true
+			}
+		}
+	}
+	if all && len(required) == 0 {
+		for name := range properties {
+			required[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if required[names[i]] != required[names[j]] {
+			return required[names[i]]
+		}
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		prop, ok := properties[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		key := keyNode(name)
+		key.HeadComment = b.description(prop)
+		value := b.value(name, prop, depth+1, false)
+		if !required[name] {
+			markOptional(key, value)
+		}
+		obj.Content = append(obj.Content, key, value)
+	}
+}
+
+// value returns the example value of a property: its default, its first allowed value, the
+// expanded object or array, or a placeholder.
+func (b *builder) value(name string, prop map[string]any, depth int, item bool) *yaml.Node {
+	if value, ok := prop["default"]; ok {
+		if node := encodeNode(value); node != nil {
+			return node
+		}
+	}
+	if enum, ok := prop["enum"].([]any); ok && len(enum) > 0 {
+		if node := encodeNode(enum[0]); node != nil {
+			return node
+		}
+	}
+
+	switch prop["type"] {
+	case "object":
+		properties, _ := prop["properties"].(map[string]any)
+		if len(properties) == 0 || depth > b.opts.MaxDepth {
+			return &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle}
+		}
+		obj := &yaml.Node{Kind: yaml.MappingNode}
+		b.fields(obj, prop, depth, item)
+		if item && len(obj.Content) > 0 {
+			// A comment above the first field would be emitted after the dash of the item.
+			obj.HeadComment, obj.Content[0].HeadComment = obj.Content[0].HeadComment, ""
+		}
+		return obj
+	case "array":
+		items, ok := prop["items"].(map[string]any)
+		if !ok || depth > b.opts.MaxDepth {
+			return &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		}
+		// Items are named after the array so that e.g. ports get a port number.
+		return &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{b.value(name, items, depth+1, true)}}
+	}
+	return placeholder(name, prop)
+}
+
+// description returns the description of the property, shortened as set by Options.MaxDescription.
+func (b *builder) description(prop map[string]any) string {
+	description, _ := prop["description"].(string)
+	description = strings.TrimSpace(description)
+	if b.opts.MaxDescription <= 0 {
+		return description
+	}
+
+	description, _, _ = strings.Cut(description, "\n")
+	if sentence, _, found := strings.Cut(description, ". "); found {
+		description = sentence + "."
+	}
+	description = strings.TrimSpace(description)
+	if runes := []rune(description); len(runes) > b.opts.MaxDescription {
+		description = string(runes[:b.opts.MaxDescription]) + "..."
+	}
+	return description
+}
+
+// placeholder returns a scalar suitable as an example value of the property.
+func placeholder(name string, prop map[string]any) *yaml.Node {
+	lowerName := strings.ToLower(name)
+	switch prop["type"] {
+	case "string":
+		switch {
+		case prop["format"] == "date-time":
+			return stringNode("2025-01-01T00:00:00Z")
+		case strings.Contains(lowerName, "image"):
+			return stringNode("nginx:latest")
+		case strings.Contains(lowerName, "host"):
+			return stringNode("example.com")
+		}
+		return stringNode("example-" + name)
+	case "integer", "number":
+		switch {
+		case strings.Contains(lowerName, "port"):
+			return intNode("8080")
+		case strings.Contains(lowerName, "replica"):
+			return intNode("2")
+		}
+		return intNode("1")
+	case "boolean":
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "false"}
+	}
+	if prop["x-kubernetes-int-or-string"] == true {
+		return intNode("1")
+	}
+	if prop["x-kubernetes-preserve-unknown-fields"] == true {
+		return &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle}
+	}
+	return stringNode("value")
+}
+
+// encodeNode returns value as a node, with collections in flow style to keep them on one line.
+func encodeNode(value any) *yaml.Node {
+	node := &yaml.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil
+	}
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style = yaml.FlowStyle
+	}
+	return node
+}
+
+// markOptional tags the line of a field so that commentOutOptional comments it out along
+// with its nested lines. Comments of block collections are emitted after the key, those of
+// scalars and flow collections after the value.
+func markOptional(key, value *yaml.Node) {
+	if value.Kind == yaml.ScalarNode || value.Style&yaml.FlowStyle != 0 {
+		value.LineComment = optionalMarker
+	} else {
+		key.LineComment = optionalMarker
+	}
+}
+
+// commentOutOptional comments out the lines tagged by markOptional and the lines nested
+// below them, placing the "#" at the indentation of the optional field so that the whole
+// block can be uncommented at once.
+func commentOutOptional(text string) string {
+	marker := " # " + optionalMarker
+	lines := strings.Split(text, "\n")
+	commentAt := -1
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+		if commentAt >= 0 && trimmed != "" && indent <= commentAt {
+			commentAt = -1
+		}
+		if strings.HasSuffix(line, marker) {
+			line = strings.TrimSuffix(line, marker)
+			if commentAt < 0 {
+				commentAt = indent
+			}
+		}
+		if commentAt >= 0 && trimmed != "" {
+			line = line[:commentAt] + "# " + line[commentAt:]
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func keyNode(name string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: name}
+}
+
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func intNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value}
+}
@@ -19,6 +19,7 @@ package tui
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
@@ -29,10 +30,47 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/pehlicd/crd-wizard/internal/config"
 	"github.com/pehlicd/crd-wizard/internal/k8s"
 	"github.com/pehlicd/crd-wizard/internal/models"
 )
 
+// usageFilter narrows the CRD list down by whether CRDs have instances.
+type usageFilter int
+
+const (
+	usageAll usageFilter = iota
+	usageInUse
+	usageUnused
+)
+
+// next returns the filter that follows f when cycling through them.
+func (f usageFilter) next() usageFilter {
+	return (f + 1) % 3
+}
+
+// matches reports whether a CRD with the given number of instances passes the filter.
+func (f usageFilter) matches(instanceCount int) bool {
+	switch f {
+	case usageInUse:
+		return instanceCount > 0
+	case usageUnused:
+		return instanceCount == 0
+	}
+	return true
+}
+
+// title returns the suffix of the list title that names the filter, empty for usageAll.
+func (f usageFilter) title() string {
+	switch f {
+	case usageInUse:
+		return "in use"
+	case usageUnused:
+		return "unused"
+	}
+	return ""
+}
+
 type crdListModel struct {
 	client        *k8s.Client
 	table         table.Model
@@ -44,13 +82,15 @@ type crdListModel struct {
 	filtering     bool
 	err           error
 	width, height int
-	infoVisible   bool
-	clusterInfo   models.ClusterInfo
 	keys          KeyMap
 	help          help.Model
+	prefs         *config.TUI
+	// bookmarksOnly hides every CRD that is not bookmarked.
+	bookmarksOnly bool
+	usage         usageFilter
 }
 
-func newCRDListModel(client *k8s.Client, targetCRDs []models.CRD) crdListModel {
+func newCRDListModel(client *k8s.Client, keys KeyMap, prefs *config.TUI, targetCRDs []models.CRD) crdListModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4"))
@@ -73,7 +113,7 @@ func newCRDListModel(client *k8s.Client, targetCRDs []models.CRD) crdListModel {
 	})
 
 	ti := textinput.New()
-	ti.Placeholder = "Filter by name or kind..."
+	ti.Placeholder = "Filter by name, kind or short name..."
 	ti.Focus()
 	ti.CharLimit = 156
 	ti.Width = 50
@@ -85,9 +125,9 @@ func newCRDListModel(client *k8s.Client, targetCRDs []models.CRD) crdListModel {
 		textInput:    ti,
 		loading:      true,
 		filteredCRDs: targetCRDs,
-		infoVisible:  false,
-		keys:         DefaultKeyMap(),
+		keys:         keys,
 		help:         help.New(),
+		prefs:        prefs,
 	}
 }
 
@@ -139,25 +179,29 @@ func (m crdListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case crdsLoadedMsg:
 		m.loading = false
 		m.crds = msg.crds
-		m.filteredCRDs = msg.crds
-		m.updateTableRows()
-
-	case showInfoMsg:
-		m.clusterInfo = msg.ClusterInfo
-		m.infoVisible = true
-		return m, nil
+		m.filterTable()
 
 	case errMsg:
 		m.err = msg.err
 		m.loading = false
 
-	case tea.KeyMsg:
-		if m.infoVisible {
-			if key.Matches(msg, m.keys.Quit, m.keys.Back, m.keys.Info) {
-				m.infoVisible = false
-			}
+	case tea.MouseMsg:
+		if m.loading || m.filtering || !isLeftClick(msg) {
+			break
+		}
+		row := tableRowAt(m.table, msg.Y-m.tableTop())
+		if row < 0 || row >= len(m.filteredCRDs) {
 			return m, nil
 		}
+		// Clicking the selected row opens it, clicking any other row selects it.
+		if row == m.table.Cursor() {
+			selectedCRD := m.filteredCRDs[row]
+			return m, func() tea.Msg { return showInstancesMsg{crd: selectedCRD} }
+		}
+		m.table.SetCursor(row)
+		return m, nil
+
+	case tea.KeyMsg:
 		if m.filtering {
 			if key.Matches(msg, m.keys.Enter, m.keys.Cancel) {
 				m.filtering = false
@@ -188,16 +232,25 @@ func (m crdListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return crdsLoadedMsg{crds}
 			}
-		} else if key.Matches(msg, m.keys.Info) {
-			return m, func() tea.Msg {
-				clusterInfo, err := m.client.GetClusterInfo()
-				if err != nil {
-					return errMsg{err}
+		} else if key.Matches(msg, m.keys.Bookmark) {
+			if selected := m.SelectedItem(); selected != nil {
+				name := selected.Name
+				m.prefs.ToggleBookmark(name)
+				m.applyFilters()
+				m.selectCRD(name)
+				if err := m.prefs.Save(); err != nil {
+					return m, func() tea.Msg { return errMsg{err} }
 				}
-				return showInfoMsg{clusterInfo}
 			}
-		} else if key.Matches(msg, m.keys.Help) {
-			m.help.ShowAll = !m.help.ShowAll
+			return m, nil
+		} else if key.Matches(msg, m.keys.Bookmarks) {
+			m.bookmarksOnly = !m.bookmarksOnly
+			m.filterTable()
+			return m, nil
+		} else if key.Matches(msg, m.keys.Usage) {
+			m.usage = m.usage.next()
+			m.filterTable()
+			return m, nil
 		}
 	}
 
@@ -210,22 +263,50 @@ func (m crdListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *crdListModel) filterTable() {
+	m.applyFilters()
+	m.table.SetCursor(0)
+}
+
+// applyFilters rebuilds the visible rows from the filter input, the bookmarks and the usage
+// filter, listing bookmarked CRDs first.
+func (m *crdListModel) applyFilters() {
 	val := strings.ToLower(m.textInput.Value())
-	if val == "" {
-		m.filteredCRDs = m.crds
-	} else {
-		filtered := make([]models.CRD, 0)
-		for _, crd := range m.crds {
-			if strings.Contains(strings.ToLower(crd.Name), val) || strings.Contains(strings.ToLower(crd.Kind), val) {
-				filtered = append(filtered, crd)
-			}
+	filtered := make([]models.CRD, 0, len(m.crds))
+	for _, crd := range m.crds {
+		if m.bookmarksOnly && !m.prefs.IsBookmarked(crd.Name) {
+			continue
+		}
+		if !m.usage.matches(crd.InstanceCount) {
+			continue
+		}
+		if val == "" || strings.Contains(strings.ToLower(crd.Name), val) || strings.Contains(strings.ToLower(crd.Kind), val) || slices.Contains(crd.ShortNames, val) {
+			filtered = append(filtered, crd)
 		}
-		m.filteredCRDs = filtered
 	}
-	m.table.SetCursor(0)
+	slices.SortStableFunc(filtered, func(a, b models.CRD) int {
+		ba, bb := m.prefs.IsBookmarked(a.Name), m.prefs.IsBookmarked(b.Name)
+		switch {
+		case ba && !bb:
+			return -1
+		case !ba && bb:
+			return 1
+		}
+		return 0
+	})
+	m.filteredCRDs = filtered
 	m.updateTableRows()
 }
 
+// selectCRD moves the cursor to the CRD with the given name, if it is visible.
+func (m *crdListModel) selectCRD(name string) {
+	for i, crd := range m.filteredCRDs {
+		if crd.Name == name {
+			m.table.SetCursor(i)
+			return
+		}
+	}
+}
+
 func (m *crdListModel) updateTableRows() {
 	crdsCount := len(m.filteredCRDs)
 	if crdsCount < 1 {
@@ -239,11 +320,20 @@ func (m *crdListModel) updateTableRows() {
 		if crd.InstanceCount == 0 {
 			instanceText = "Not in use"
 		}
-		rows[i] = table.Row{crd.Kind, crd.Name, instanceText}
+		kind := crd.Kind
+		if m.prefs.IsBookmarked(crd.Name) {
+			kind = "★ " + kind
+		}
+		rows[i] = table.Row{kind, crd.Name, instanceText}
 	}
 	m.table.SetRows(rows)
 }
 
+// tableTop returns the screen line the table starts at, following the layout of View.
+func (m crdListModel) tableTop() int {
+	return AppStyle.GetMarginTop() + AppStyle.GetBorderTopSize() + lipgloss.Height(TitleStyle.PaddingBottom(1).Render(" "))
+}
+
 func (m crdListModel) SelectedItem() *models.CRD {
 	if m.table.Cursor() >= 0 && m.table.Cursor() < len(m.filteredCRDs) {
 		return &m.filteredCRDs[m.table.Cursor()]
@@ -259,39 +349,35 @@ func (m crdListModel) View() string {
 		return fmt.Sprintf("\n   %s Fetching CRDs from cluster...\n\n", m.spinner.View())
 	}
 
-	if m.infoVisible {
-		// Prepare the content for the modal
-		var infoBuilder strings.Builder
-		infoBuilder.WriteString(TitleStyle.Render("Cluster Information") + "\n\n")
-		infoBuilder.WriteString(fmt.Sprintf("Cluster Name: %s\n", m.clusterInfo.ClusterName))
-		infoBuilder.WriteString(fmt.Sprintf("K8s Version: %s\n", m.clusterInfo.ServerVersion))
-		infoBuilder.WriteString(fmt.Sprintf("CRDs Loaded: %d", len(m.crds)))
-
-		modalContent := ModalStyle.Render(infoBuilder.String())
-		help := HelpStyle.Render("[Esc/q/i] Close Info")
-
-		// Combine modal and help, then center it on the screen
-		finalView := lipgloss.JoinVertical(lipgloss.Center, modalContent, help)
-		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, finalView)
-	}
-
 	var viewContent string
 	var helpView string
 	titlestyle := TitleStyle.PaddingBottom(1)
+	clusterTag := lipgloss.NewStyle().PaddingLeft(1).Render(ClusterTag(m.client.ClusterName) + ReadOnlyTag(m.client.ReadOnly()))
 
 	if m.filtering {
 		// Temporary help keys for filtering mode
 		// We could define a separate keymap for this mode or just show relevant keys
 		helpView = HelpStyle.Render("[Enter/Esc] Confirm/Cancel")
 		viewContent = lipgloss.JoinVertical(lipgloss.Left,
-			titlestyle.Render("️🧙 CRD Wizard"),
+			lipgloss.JoinHorizontal(lipgloss.Top, titlestyle.Render("️🧙 CRD Wizard"), clusterTag),
 			m.textInput.View(),
 			m.table.View(),
 		)
 	} else {
 		helpView = HelpStyle.Render(m.help.View(m.keys))
+		title := "🧙 CRD Wizard - CRD Selector"
+		var filters []string
+		if m.bookmarksOnly {
+			filters = append(filters, "bookmarks")
+		}
+		if usage := m.usage.title(); usage != "" {
+			filters = append(filters, usage)
+		}
+		if len(filters) > 0 {
+			title += " (" + strings.Join(filters, ", ") + ")"
+		}
 		viewContent = lipgloss.JoinVertical(lipgloss.Left,
-			titlestyle.Render("🧙 CRD Wizard - CRD Selector"),
+			lipgloss.JoinHorizontal(lipgloss.Top, titlestyle.Render(title), clusterTag),
 			m.table.View(),
 		)
 	}
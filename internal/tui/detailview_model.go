@@ -20,12 +20,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/alecthomas/chroma/v2/formatters"
 	"github.com/alecthomas/chroma/v2/lexers"
 	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -52,24 +54,57 @@ type detailModel struct {
 	instance      unstructured.Unstructured
 	events        []corev1.Event
 	graph         *models.ResourceGraph
-	yamlContent   string
+	yamlSections  []yamlSection
+	yamlCursor    int
 	eventsContent string
 	graphContent  string
+	content       string // The unhighlighted content of the active tab
 	viewport      viewport.Model
+	search        viewportSearch
+	keys          KeyMap
 	spinner       spinner.Model
 	activeTab     detailViewTab
 	loading       bool
+	// graphProgress receives the progress of the cluster scan behind the resource graph.
+	graphProgress chan graphProgressMsg
+	scanned       graphProgressMsg
 	err           error
 	width, height int
 }
 
+// yamlSection is a top-level field of the instance YAML that can be folded in the definition tab.
+type yamlSection struct {
+	key string
+	// lines holds the highlighted YAML of the field, starting with the line of its key.
+	lines  []string
+	folded bool
+}
+
+// foldable reports whether the section spans more than its key line.
+func (s yamlSection) foldable() bool {
+	return len(s.lines) > 1
+}
+
+// height returns the number of lines the section takes up when rendered.
+func (s yamlSection) height() int {
+	if s.folded {
+		return 1
+	}
+	return len(s.lines)
+}
+
+// graphProgressMsg reports how many resource types the resource graph scan has listed.
+type graphProgressMsg struct {
+	scanned, total int
+}
+
 type contentLoadedMsg struct {
-	yamlStr string
-	events  []corev1.Event
-	graph   *models.ResourceGraph
+	yamlSections []yamlSection
+	events       []corev1.Event
+	graph        *models.ResourceGraph
 }
 
-func newDetailModel(client *k8s.Client, crd models.CRD, instance unstructured.Unstructured, width, height int) detailModel {
+func newDetailModel(client *k8s.Client, keys KeyMap, crd models.CRD, instance unstructured.Unstructured, width, height int) detailModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4"))
@@ -81,16 +116,20 @@ func newDetailModel(client *k8s.Client, crd models.CRD, instance unstructured.Un
 		crd:      crd,
 		instance: instance,
 		viewport: vp,
-		spinner:  s,
-		loading:  true,
-		width:    width,
-		height:   height,
+		search:   newViewportSearch(),
+		// Buffered so that the scan never waits for the view to catch up.
+		graphProgress: make(chan graphProgressMsg, 1),
+		keys:          keys,
+		spinner:       s,
+		loading:       true,
+		width:         width,
+		height:        height,
 	}
 }
 
 func (m detailModel) Init() tea.Cmd {
-	return tea.Batch(m.spinner.Tick, func() tea.Msg {
-		var yamlStr string
+	return tea.Batch(m.spinner.Tick, m.waitForGraphProgress(), func() tea.Msg {
+		var sections []yamlSection
 		var events []corev1.Event
 		var graph *models.ResourceGraph
 		var wg sync.WaitGroup
@@ -106,12 +145,7 @@ func (m detailModel) Init() tea.Cmd {
 					delete(metadata, "managedFields")
 				}
 			}
-			yamlBytes, err := yaml.Marshal(instance.Object)
-			if err != nil {
-				err1 = err
-				return
-			}
-			yamlStr, err1 = highlightYAML(string(yamlBytes))
+			sections, err1 = buildYAMLSections(instance.Object)
 		}()
 		go func() {
 			defer wg.Done()
@@ -119,8 +153,12 @@ func (m detailModel) Init() tea.Cmd {
 		}()
 		go func() {
 			defer wg.Done()
+			defer close(m.graphProgress)
 			// Fetch the resource graph using the actual client method.
-			graph, err3 = m.client.GetResourceGraph(context.Background(), string(m.instance.GetUID()))
+			graph, err3 = m.client.GetResourceGraphWithOptions(context.Background(), string(m.instance.GetUID()), k8s.GraphOptions{
+				Progress: m.reportGraphProgress,
+				Kinds:    []string{m.instance.GetKind()},
+			})
 		}()
 		wg.Wait()
 
@@ -134,10 +172,31 @@ func (m detailModel) Init() tea.Cmd {
 			return errMsg{err3}
 		}
 
-		return contentLoadedMsg{yamlStr: yamlStr, events: events, graph: graph}
+		return contentLoadedMsg{yamlSections: sections, events: events, graph: graph}
 	})
 }
 
+// reportGraphProgress hands the latest scan progress to the view, replacing any
+// progress the view has not picked up yet.
+func (m detailModel) reportGraphProgress(scanned, total int) {
+	select {
+	case <-m.graphProgress:
+	default:
+	}
+	m.graphProgress <- graphProgressMsg{scanned: scanned, total: total}
+}
+
+// waitForGraphProgress waits for the next progress report of the resource graph scan.
+func (m detailModel) waitForGraphProgress() tea.Cmd {
+	return func() tea.Msg {
+		progress, ok := <-m.graphProgress
+		if !ok {
+			return nil
+		}
+		return progress
+	}
+}
+
 func (m detailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
@@ -147,9 +206,13 @@ func (m detailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width, m.height = msg.Width, msg.Height
 		m.viewport.Width = msg.Width - 4
 		m.viewport.Height = msg.Height - 8
+	case graphProgressMsg:
+		m.scanned = msg
+		cmds = append(cmds, m.waitForGraphProgress())
 	case contentLoadedMsg:
 		m.loading = false
-		m.yamlContent = msg.yamlStr
+		m.yamlSections = msg.yamlSections
+		m.yamlCursor = max(m.nextFoldable(-1, 1), 0)
 		m.events = msg.events
 		m.graph = msg.graph
 		m.eventsContent = m.formatEvents()
@@ -159,15 +222,46 @@ func (m detailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = msg.err
 		m.loading = false
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q":
+		if m.search.typing {
+			changed, cmd := m.search.update(msg, m.keys)
+			if changed {
+				m.setContent(m.content)
+				m.search.reveal(&m.viewport)
+			}
+			return m, cmd
+		}
+		if !m.loading {
+			switch {
+			case key.Matches(msg, m.keys.Filter):
+				return m, m.search.start()
+			case key.Matches(msg, m.keys.NextMatch, m.keys.PrevMatch):
+				step := 1
+				if key.Matches(msg, m.keys.PrevMatch) {
+					step = -1
+				}
+				if m.search.step(step) {
+					m.setContent(m.content)
+					m.search.reveal(&m.viewport)
+				}
+				return m, nil
+			case key.Matches(msg, m.keys.Cancel) && m.search.active():
+				m.search.clear()
+				m.setContent(m.content)
+				return m, nil
+			}
+		}
+		if m.activeTab == definitionTab && !m.loading && m.handleFoldKeys(msg) {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
-		case "b", "esc":
+		case key.Matches(msg, m.keys.Back):
 			return m, func() tea.Msg { return goBackMsg{} }
-		case "tab", "right", "l":
+		case key.Matches(msg, m.keys.Tab, m.keys.Right):
 			m.activeTab = (m.activeTab + 1) % 3
 			m.switchTabContent()
-		case "left", "h":
+		case key.Matches(msg, m.keys.Left, m.keys.ShiftTab):
 			m.activeTab--
 			if m.activeTab < definitionTab {
 				m.activeTab = graphTab
@@ -188,15 +282,133 @@ func (m detailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m *detailModel) switchTabContent() {
 	switch m.activeTab {
 	case definitionTab:
-		m.viewport.SetContent(m.yamlContent)
+		m.setContent(m.renderYAML())
 	case eventsTab:
-		m.viewport.SetContent(m.eventsContent)
+		m.setContent(m.eventsContent)
 	case graphTab:
-		m.viewport.SetContent(m.graphContent)
+		m.setContent(m.graphContent)
 	}
 	m.viewport.GotoTop()
 }
 
+// setContent shows content in the viewport with the matches of the active search highlighted.
+func (m *detailModel) setContent(content string) {
+	m.content = content
+	m.viewport.SetContent(m.search.apply(content))
+}
+
+// buildYAMLSections marshals each top-level field of the object into its own highlighted section.
+func buildYAMLSections(object map[string]any) ([]yamlSection, error) {
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sections := make([]yamlSection, 0, len(keys))
+	for _, k := range keys {
+		yamlBytes, err := yaml.Marshal(yaml.MapSlice{{Key: k, Value: object[k]}})
+		if err != nil {
+			return nil, err
+		}
+		highlighted, err := highlightYAML(string(yamlBytes))
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, yamlSection{
+			key:   k,
+			lines: strings.Split(strings.TrimRight(highlighted, "\n"), "\n"),
+		})
+	}
+	return sections, nil
+}
+
+// handleFoldKeys moves between and folds the sections of the definition tab.
+// It reports whether the key was handled.
+func (m *detailModel) handleFoldKeys(msg tea.KeyMsg) bool {
+	if len(m.yamlSections) == 0 {
+		return false
+	}
+	switch {
+	case key.Matches(msg, m.keys.NextSection):
+		m.yamlCursor = m.nextFoldable(m.yamlCursor, 1)
+	case key.Matches(msg, m.keys.PrevSection):
+		m.yamlCursor = m.nextFoldable(m.yamlCursor, -1)
+	case key.Matches(msg, m.keys.Expand):
+		if section := &m.yamlSections[m.yamlCursor]; section.foldable() {
+			section.folded = !section.folded
+		}
+	case key.Matches(msg, m.keys.FoldAll):
+		// Fold everything unless everything is already folded, then unfold everything.
+		fold := false
+		for _, section := range m.yamlSections {
+			if section.foldable() && !section.folded {
+				fold = true
+				break
+			}
+		}
+		for i := range m.yamlSections {
+			m.yamlSections[i].folded = fold && m.yamlSections[i].foldable()
+		}
+	default:
+		return false
+	}
+
+	m.setContent(m.renderYAML())
+	// Keep the selected section in view.
+	offset := 0
+	for _, section := range m.yamlSections[:m.yamlCursor] {
+		offset += section.height()
+	}
+	if offset < m.viewport.YOffset || offset >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(offset)
+	}
+	return true
+}
+
+// nextFoldable returns the index of the next foldable section from the given one in the
+// given direction, or the given index when there is none.
+func (m detailModel) nextFoldable(from, step int) int {
+	for i := from + step; i >= 0 && i < len(m.yamlSections); i += step {
+		if m.yamlSections[i].foldable() {
+			return i
+		}
+	}
+	return from
+}
+
+// renderYAML renders the YAML sections, marking the selected one and collapsing folded ones.
+func (m detailModel) renderYAML() string {
+	var b strings.Builder
+	for i, section := range m.yamlSections {
+		marker := "  "
+		if section.foldable() {
+			marker = "▾ "
+			if section.folded {
+				marker = "▸ "
+			}
+		}
+		if i == m.yamlCursor {
+			marker = SelectedStyle.Render(marker)
+		}
+
+		if section.folded {
+			b.WriteString(fmt.Sprintf("%s%s: %s\n", marker, section.key, MutedStyle.Render(fmt.Sprintf("… %d lines", len(section.lines)-1))))
+			continue
+		}
+		for j, line := range section.lines {
+			if j == 0 {
+				b.WriteString(marker)
+			} else {
+				b.WriteString("  ")
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
 func (m detailModel) formatEvents() string {
 	if len(m.events) == 0 {
 		return "No events found for this resource."
@@ -214,7 +426,7 @@ func (m detailModel) formatEvents() string {
 		}
 
 		b.WriteString(fmt.Sprintf("%s  %s  %s  %s\n",
-			k8s.HumanReadableAge(t),
+			AgeStyle(t).Render(fmt.Sprintf("%-4s", k8s.HumanReadableAge(t))),
 			eventType,
 			e.Reason,
 			e.Message,
@@ -227,132 +439,28 @@ func (m detailModel) formatGraph() string {
 	if m.graph == nil || len(m.graph.Nodes) == 0 {
 		return "No resource graph available."
 	}
-
-	var b strings.Builder
-	nodes := make(map[string]models.Node)
-	for _, n := range m.graph.Nodes {
-		nodes[n.ID] = n
+	if m.graph.Truncated {
+		return WarnStyle.Render("The scan hit its object limit (--graph-max-objects), the graph may be partial.") + "\n\n" + m.graph.Tree(m.nodeLabel)
 	}
-
-	adj := make(map[string][]string)
-	isTarget := make(map[string]bool)
-	for _, e := range m.graph.Edges {
-		adj[e.Source] = append(adj[e.Source], e.Target)
-		isTarget[e.Target] = true
-	}
-
-	// Find root nodes for rendering the tree structure.
-	// A root is any node that is not a target of an edge.
-	var roots []string
-	for _, n := range m.graph.Nodes {
-		if !isTarget[n.ID] {
-			roots = append(roots, n.ID)
-		}
-	}
-
-	for _, rootID := range roots {
-		m.dfsRender(&b, rootID, "", true, nodes, adj)
-	}
-
-	return b.String()
+	return m.graph.Tree(m.nodeLabel)
 }
 
-func (m detailModel) dfsRender(b *strings.Builder, nodeID, prefix string, isLast bool, nodes map[string]models.Node, adj map[string][]string) {
-	node, ok := nodes[nodeID]
-	if !ok {
-		return
-	}
-
-	// Style the node type with a color
+// nodeLabel renders a graph node with its kind colored, highlighting the resource this detail view is for.
+func (m detailModel) nodeLabel(node models.Node) string {
 	kindColor := getColorForKind(node.Type)
 	styledType := lipgloss.NewStyle().Foreground(kindColor).Render(node.Type)
-	label := fmt.Sprintf("[%s: %s]", styledType, node.Label)
+	label := fmt.Sprintf("[%s: %s]", styledType, node.QualifiedName())
 
-	// Highlight the resource this detail view is for
 	if node.ID == string(m.instance.GetUID()) {
 		label = lipgloss.NewStyle().Bold(true).Render(label + " [*]")
 	}
-
-	b.WriteString(prefix)
-	if isLast {
-		b.WriteString("└── ")
-		prefix += "    "
-	} else {
-		b.WriteString("├── ")
-		prefix += "│   "
-	}
-	b.WriteString(label)
-	b.WriteString("\n")
-
-	children := adj[nodeID]
-	for i, childID := range children {
-		m.dfsRender(b, childID, prefix, i == len(children)-1, nodes, adj)
-	}
+	return label
 }
 
 // getColorForKind returns a specific color for each Kubernetes resource type
-// to make the graph more readable, based on the provided color scheme.
+// to make the graph more readable, shared with the exported graphs.
 func getColorForKind(kind string) lipgloss.Color {
-	switch kind {
-	// Workload Resources
-	case "Pod":
-		return lipgloss.Color("#0EA5E9") // sky
-	case "Deployment":
-		return lipgloss.Color("#10B981") // emerald
-	case "StatefulSet":
-		return lipgloss.Color("#F59E0B") // amber
-	case "DaemonSet":
-		return lipgloss.Color("#14B8A6") // teal
-	case "Job":
-		return lipgloss.Color("#8B5CF6") // violet
-	case "CronJob":
-		return lipgloss.Color("#D946EF") // fuchsia
-	case "ReplicaSet":
-		return lipgloss.Color("#06B6D4") // cyan
-	case "ReplicationController":
-		return lipgloss.Color("#3B82F6") // blue
-
-	// Service Discovery & Load Balancing
-	case "Service":
-		return lipgloss.Color("#F97316") // orange
-	case "Ingress":
-		return lipgloss.Color("#6366F1") // indigo
-	case "Endpoint", "EndpointSlice":
-		return lipgloss.Color("#EC4899") // pink
-
-	// Configuration & Storage
-	case "ConfigMap":
-		return lipgloss.Color("#84CC16") // lime
-	case "Secret":
-		return lipgloss.Color("#EF4444") // red
-	case "PersistentVolume":
-		return lipgloss.Color("#EAB308") // yellow
-	case "PersistentVolumeClaim":
-		return lipgloss.Color("#22C55E") // green
-	case "StorageClass":
-		return lipgloss.Color("#A855F7") // purple
-
-	// Security & RBAC
-	case "ServiceAccount":
-		return lipgloss.Color("#71717A") // zinc
-	case "Role", "ClusterRole":
-		return lipgloss.Color("#38BDF8") // sky
-	case "RoleBinding", "ClusterRoleBinding":
-		return lipgloss.Color("#FB923C") // orange
-
-	// Policy Resources
-	case "NetworkPolicy":
-		return lipgloss.Color("#22D3EE") // cyan
-	case "PodDisruptionBudget":
-		return lipgloss.Color("#34D399") // emerald
-
-	// Custom Resources
-	case "CustomResourceDefinition":
-		return lipgloss.Color("#818CF8") // indigo
-
-	default:
-		return lipgloss.Color("#FFFFFF") // Default to white
-	}
+	return lipgloss.Color(models.KindColor(kind))
 }
 
 func (m detailModel) View() string {
@@ -360,10 +468,16 @@ func (m detailModel) View() string {
 		return fmt.Sprintf("\n   %s %s\n\n", ErrStyle.Render("Error:"), m.err)
 	}
 	if m.loading {
-		return fmt.Sprintf("\n   %s Loading details for %s...\n\n", m.spinner.View(), m.instance.GetName())
+		progress := ""
+		if m.scanned.total > 0 {
+			progress = fmt.Sprintf("\n   Scanning cluster: %d/%d resource types", m.scanned.scanned, m.scanned.total)
+		}
+		return fmt.Sprintf("\n   %s Loading details for %s...%s\n\n", m.spinner.View(), m.instance.GetName(), progress)
 	}
 
+	created := m.instance.GetCreationTimestamp().Time
 	title := fmt.Sprintf("%s: %s/%s", m.crd.Kind, m.instance.GetNamespace(), m.instance.GetName())
+	age := AgeStyle(created).Render(fmt.Sprintf("age %s (%s)", k8s.HumanReadableAge(created), k8s.Timestamp(created)))
 
 	tabNames := []string{"Graph", "Definition", "Events"}
 	tabs := make([]string, len(tabNames))
@@ -377,11 +491,19 @@ func (m detailModel) View() string {
 	tabHeader := lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
 
 	help := "[↑/↓] Scroll | [Tab] Switch Pane | [b] Back | [q] Quit"
+	if m.activeTab == definitionTab {
+		help = fmt.Sprintf("[↑/↓] Scroll | [%s/%s] Section | [%s] Fold | [%s] Fold All | [Tab] Switch Pane | [b] Back | [q] Quit",
+			m.keys.PrevSection.Help().Key, m.keys.NextSection.Help().Key, m.keys.Expand.Help().Key, m.keys.FoldAll.Help().Key)
+	}
+	help = strings.Replace(help, "[Tab]", "[/] Search | [Tab]", 1)
+	if m.search.active() {
+		help = m.search.View()
+	}
 
 	titleStyle := TitleStyle.Margin(0, 0, 1)
 
 	view := lipgloss.JoinVertical(lipgloss.Left,
-		titleStyle.Render(title),
+		lipgloss.JoinHorizontal(lipgloss.Top, titleStyle.Render(title), "  ", age, "  ", ClusterTag(m.client.ClusterName)),
 		tabHeader,
 		m.viewport.View(),
 	) + "\n" + HelpStyle.Render(help)
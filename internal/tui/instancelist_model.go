@@ -19,14 +19,16 @@ package tui
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
-	"time"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -69,6 +71,12 @@ type schemaNode struct {
 	expanded    bool
 }
 
+// instanceMatch pairs an instance with the field path that matched the content search.
+type instanceMatch struct {
+	instance unstructured.Unstructured
+	path     string
+}
+
 type instanceListModel struct {
 	client          *k8s.Client
 	crd             models.CRD
@@ -76,7 +84,11 @@ type instanceListModel struct {
 	table           table.Model
 	spinner         spinner.Model
 	viewport        viewport.Model
+	schemaSearch    viewportSearch // In-content search of the schema viewport
 	instances       []unstructured.Unstructured
+	searchInput     textinput.Model
+	searching       bool
+	matches         []instanceMatch // Instances matching the content search, in table order
 	loading         bool
 	err             error
 	width, height   int
@@ -84,11 +96,13 @@ type instanceListModel struct {
 	schemaRoot      []*schemaNode // The full tree
 	flattenedSchema []*schemaNode // The visible nodes for rendering and navigation
 	schemaCursor    int           // The cursor position in the flattenedSchema
+	copiedPath      string        // The field path last copied to the clipboard
+	copyErr         error         // Why copying copiedPath failed, if it did
 	keys            KeyMap
 	help            help.Model
 }
 
-func newInstanceListModel(client *k8s.Client, crd models.CRD, width, height int) instanceListModel {
+func newInstanceListModel(client *k8s.Client, keys KeyMap, crd models.CRD, width, height int) instanceListModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4"))
@@ -113,22 +127,28 @@ func newInstanceListModel(client *k8s.Client, crd models.CRD, width, height int)
 		Selected: SelectedStyle,
 	})
 
+	ti := textinput.New()
+	ti.Placeholder = "Search instance contents..."
+	ti.CharLimit = 156
+
 	vp := viewport.New(width-4, height-8) // Placeholder dimensions
 	vp.Style = lipgloss.NewStyle().Padding(0, 1)
 	vp.SetContent("Loading schema...")
 
 	return instanceListModel{
-		client:    client,
-		crd:       crd,
-		table:     tbl,
-		spinner:   s,
-		viewport:  vp,
-		loading:   true,
-		width:     width,
-		height:    height,
-		activeTab: schemaTab,
-		keys:      DefaultKeyMap(),
-		help:      help.New(),
+		client:       client,
+		crd:          crd,
+		table:        tbl,
+		spinner:      s,
+		viewport:     vp,
+		searchInput:  ti,
+		schemaSearch: newViewportSearch(),
+		loading:      true,
+		width:        width,
+		height:       height,
+		activeTab:    schemaTab,
+		keys:         keys,
+		help:         help.New(),
 	}
 }
 
@@ -165,7 +185,7 @@ func (m instanceListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case instancesLoadedMsg:
 		m.loading = false
 		m.instances = msg.instances
-		m.updateTableRows()
+		m.applySearch()
 		m.recalculateLayout()
 
 	case fullCRDLoadedMsg:
@@ -178,17 +198,80 @@ func (m instanceListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = msg.err
 		m.loading = false
 
+	case tea.MouseMsg:
+		if m.loading || m.searching || m.activeTab != instancesTab || !isLeftClick(msg) {
+			break
+		}
+		row := tableRowAt(m.table, msg.Y-m.tableTop())
+		if row < 0 || row >= len(m.matches) {
+			return m, nil
+		}
+		// Clicking the selected row opens it, clicking any other row selects it.
+		if row == m.table.Cursor() {
+			selected := m.matches[row].instance
+			return m, func() tea.Msg { return showDetailsMsg{crd: m.crd, instance: selected} }
+		}
+		m.table.SetCursor(row)
+		return m, nil
+
 	case tea.KeyMsg:
+		if m.searching {
+			if key.Matches(msg, m.keys.Enter, m.keys.Cancel) {
+				m.searching = false
+				m.searchInput.Blur()
+				if key.Matches(msg, m.keys.Cancel) {
+					m.searchInput.SetValue("")
+					m.applySearch()
+				}
+				m.recalculateLayout()
+				return m, nil
+			}
+			m.searchInput, cmd = m.searchInput.Update(msg)
+			m.applySearch()
+			return m, cmd
+		}
+
+		if m.schemaSearch.typing {
+			changed, cmd := m.schemaSearch.update(msg, m.keys)
+			if changed {
+				m.updateViewportContent()
+				m.schemaSearch.reveal(&m.viewport)
+			}
+			return m, cmd
+		}
+
 		if m.activeTab == schemaTab {
+			switch {
+			case key.Matches(msg, m.keys.Filter):
+				return m, m.schemaSearch.start()
+			case key.Matches(msg, m.keys.NextMatch, m.keys.PrevMatch):
+				step := 1
+				if key.Matches(msg, m.keys.PrevMatch) {
+					step = -1
+				}
+				if m.schemaSearch.step(step) {
+					m.updateViewportContent()
+					m.schemaSearch.reveal(&m.viewport)
+				}
+				return m, nil
+			case key.Matches(msg, m.keys.Cancel) && m.schemaSearch.active():
+				m.schemaSearch.clear()
+				m.updateViewportContent()
+				return m, nil
+			}
 			if m.handleSchemaKeys(msg) {
 				viewportNeedsUpdate = true
 			}
 		} else if m.activeTab == instancesTab && !m.loading {
 			if key.Matches(msg, m.keys.Enter) {
-				if m.table.Cursor() < len(m.instances) {
-					selected := m.instances[m.table.Cursor()]
+				if m.table.Cursor() < len(m.matches) {
+					selected := m.matches[m.table.Cursor()].instance
 					return m, func() tea.Msg { return showDetailsMsg{crd: m.crd, instance: selected} }
 				}
+			} else if key.Matches(msg, m.keys.Filter) {
+				m.searching = true
+				m.recalculateLayout()
+				return m, m.searchInput.Focus()
 			}
 		}
 
@@ -204,9 +287,6 @@ func (m instanceListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.table.Blur()
 			}
 			viewportNeedsUpdate = true
-		} else if key.Matches(msg, m.keys.Help) {
-			m.help.ShowAll = !m.help.ShowAll
-			viewportNeedsUpdate = true
 		}
 	}
 
@@ -232,7 +312,40 @@ func (m instanceListModel) View() string {
 		return AppStyle.Render(fmt.Sprintf("\n   %s %s\n\n", ErrStyle.Render("Error:"), m.err))
 	}
 
-	title := TitleStyle.Render(m.crd.Name)
+	var tabContent string
+	if m.loading {
+		tabContent = fmt.Sprintf("\n   %s Fetching details for %s...\n\n", m.spinner.View(), m.crd.Kind)
+	} else {
+		switch m.activeTab {
+		case instancesTab:
+			tabContent = m.table.View()
+			if header := m.searchHeader(); header != "" {
+				tabContent = lipgloss.JoinVertical(lipgloss.Left, header, tabContent)
+			}
+		case schemaTab:
+			tabContent = lipgloss.JoinVertical(lipgloss.Left, m.breadcrumb(), m.viewport.View())
+		}
+	}
+
+	helpView := HelpStyle.Render(m.help.View(m.keys))
+	if m.activeTab == schemaTab && m.schemaSearch.active() {
+		helpView = HelpStyle.Render(m.schemaSearch.View())
+	}
+	viewContent := lipgloss.JoinVertical(lipgloss.Left, m.headerView(), tabContent)
+
+	return AppStyle.Render(viewContent + "\n" + helpView)
+}
+
+// headerView renders the title and the tab row above the tab content.
+func (m instanceListModel) headerView() string {
+	title := lipgloss.JoinHorizontal(lipgloss.Top, TitleStyle.Render(m.crd.Name), " ", ClusterTag(m.client.ClusterName))
+	if names := m.namesSummary(); names != "" {
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, HelpStyle.Margin(0, 0, 0, 2).Render(names))
+	}
+	if m.crd.HasConversionWebhook {
+		title = lipgloss.JoinVertical(lipgloss.Left, title,
+			WarnStyle.Render("⚠ Conversion webhook: objects are converted between versions by an external webhook"))
+	}
 
 	tabHeaders := []string{"Schema", "Instances"}
 	renderedTabs := make([]string, len(tabHeaders))
@@ -246,22 +359,28 @@ func (m instanceListModel) View() string {
 	}
 	tabs := tabRowStyle.Render(lipgloss.JoinHorizontal(lipgloss.Top, renderedTabs...))
 
-	var tabContent string
-	if m.loading {
-		tabContent = fmt.Sprintf("\n   %s Fetching details for %s...\n\n", m.spinner.View(), m.crd.Kind)
-	} else {
-		switch m.activeTab {
-		case instancesTab:
-			tabContent = m.table.View()
-		case schemaTab:
-			tabContent = m.viewport.View()
-		}
-	}
+	return lipgloss.JoinVertical(lipgloss.Left, title, tabs)
+}
 
-	helpView := HelpStyle.Render(m.help.View(m.keys))
-	viewContent := lipgloss.JoinVertical(lipgloss.Left, title, tabs, tabContent)
+// tableTop returns the screen line the instance table starts at, following the layout of View.
+func (m instanceListModel) tableTop() int {
+	top := AppStyle.GetMarginTop() + AppStyle.GetBorderTopSize() + lipgloss.Height(m.headerView())
+	if header := m.searchHeader(); header != "" {
+		top += lipgloss.Height(header)
+	}
+	return top
+}
 
-	return AppStyle.Render(viewContent + "\n" + helpView)
+// namesSummary returns the kubectl-style short names and categories of the CRD.
+func (m instanceListModel) namesSummary() string {
+	var parts []string
+	if len(m.crd.ShortNames) > 0 {
+		parts = append(parts, "short names: "+strings.Join(m.crd.ShortNames, ", "))
+	}
+	if len(m.crd.Categories) > 0 {
+		parts = append(parts, "categories: "+strings.Join(m.crd.Categories, ", "))
+	}
+	return strings.Join(parts, " · ")
 }
 
 // Centralized function to handle all sizing and layout calculations.
@@ -270,9 +389,16 @@ func (m *instanceListModel) recalculateLayout() {
 
 	// Calculate height precisely based on the View layout.
 	headerHeight := 3 // Title + Tabs + Tab Margin
+	if m.crd.HasConversionWebhook {
+		headerHeight++ // Conversion webhook note
+	}
 	footerHeight := 2 // Blank line + Help text
 	contentHeight := m.height - appVerticalMargin - headerHeight - footerHeight
 	contentWidth := m.width - appHorizontalMargin
+	tableHeight := contentHeight
+	if header := m.searchHeader(); header != "" {
+		tableHeight -= lipgloss.Height(header)
+	}
 
 	// Ensure content dimensions are not negative.
 	if contentHeight < 1 {
@@ -282,10 +408,20 @@ func (m *instanceListModel) recalculateLayout() {
 		contentWidth = 1
 	}
 
+	if tableHeight < 1 {
+		tableHeight = 1
+	}
+
+	// The schema viewport shares its space with the breadcrumb line.
+	viewportHeight := contentHeight - 1
+	if viewportHeight < 1 {
+		viewportHeight = 1
+	}
+
 	// Apply new dimensions to table and viewport.
-	m.table.SetHeight(contentHeight)
+	m.table.SetHeight(tableHeight)
 	m.viewport.Width = contentWidth
-	m.viewport.Height = contentHeight
+	m.viewport.Height = viewportHeight
 
 	// Dynamically resize table columns based on content.
 	m.table.SetColumns(m.calculateColumnWidths(contentWidth))
@@ -300,7 +436,8 @@ func (m *instanceListModel) calculateColumnWidths(contentWidth int) []table.Colu
 	// Calculate max content width for dynamic columns.
 	maxNameWidth := len("NAME")
 	maxNamespaceWidth := len("NAMESPACE")
-	for _, inst := range m.instances {
+	for _, match := range m.matches {
+		inst := match.instance
 		if len(inst.GetName()) > maxNameWidth {
 			maxNameWidth = len(inst.GetName())
 		}
@@ -368,18 +505,18 @@ func (m *instanceListModel) calculateColumnWidths(contentWidth int) []table.Colu
 // handleSchemaKeys returns true if the view needs to be updated.
 func (m *instanceListModel) handleSchemaKeys(msg tea.KeyMsg) bool {
 	var changed bool
-	switch msg.String() {
-	case "up", "k":
+	switch {
+	case key.Matches(msg, m.keys.Up):
 		if m.schemaCursor > 0 {
 			m.schemaCursor--
 			changed = true
 		}
-	case "down", "j":
+	case key.Matches(msg, m.keys.Down):
 		if m.schemaCursor < len(m.flattenedSchema)-1 {
 			m.schemaCursor++
 			changed = true
 		}
-	case "enter", "l", " ":
+	case key.Matches(msg, m.keys.Expand):
 		if m.schemaCursor >= 0 && m.schemaCursor < len(m.flattenedSchema) {
 			node := m.flattenedSchema[m.schemaCursor]
 			if len(node.children) > 0 {
@@ -388,6 +525,11 @@ func (m *instanceListModel) handleSchemaKeys(msg tea.KeyMsg) bool {
 				changed = true
 			}
 		}
+	case key.Matches(msg, m.keys.CopyPath):
+		if m.schemaCursor >= 0 && m.schemaCursor < len(m.flattenedSchema) {
+			m.copiedPath = schemaPath(m.flattenedSchema[m.schemaCursor])
+			m.copyErr = clipboard.WriteAll(m.copiedPath)
+		}
 	}
 	return changed
 }
@@ -397,24 +539,91 @@ func (m *instanceListModel) updateTableRows() {
 		m.table.SetRows([]table.Row{{"No instances found for this CRD.", "", "", ""}})
 		return
 	}
-	rows := make([]table.Row, len(m.instances))
-	for i, inst := range m.instances {
-		status, _, _ := unstructured.NestedString(inst.Object, "status", "phase")
-		if status == "" {
-			if conditions, found, _ := unstructured.NestedSlice(inst.Object, "status", "conditions"); found && len(conditions) > 0 {
-				if firstCond, ok := conditions[0].(map[string]interface{}); ok {
-					status, _, _ = unstructured.NestedString(firstCond, "reason")
-				}
+	if len(m.matches) == 0 {
+		m.table.SetRows([]table.Row{{"No instances match the search.", "", "", ""}})
+		return
+	}
+	// Ages are left uncolored here, unlike in the detail view and events: the table truncates
+	// cells by counting ANSI escape sequences as text, which would cut the styled age short.
+	rows := make([]table.Row, len(m.matches))
+	for i, match := range m.matches {
+		summary := k8s.SummarizeInstance(match.instance)
+		rows[i] = table.Row{summary.Name, summary.Namespace, summary.Status, summary.Age}
+	}
+	m.table.SetRows(rows)
+}
+
+// applySearch filters the instances down to those whose contents match the
+// current search query and refreshes the table.
+func (m *instanceListModel) applySearch() {
+	query := strings.ToLower(strings.TrimSpace(m.searchInput.Value()))
+	m.matches = make([]instanceMatch, 0, len(m.instances))
+	for _, inst := range m.instances {
+		if query == "" {
+			m.matches = append(m.matches, instanceMatch{instance: inst})
+			continue
+		}
+		if path, ok := findValuePath(inst.Object, query, ""); ok {
+			m.matches = append(m.matches, instanceMatch{instance: inst, path: path})
+		}
+	}
+	m.table.SetCursor(0)
+	m.updateTableRows()
+}
+
+// searchHeader renders the search input while typing and a summary of the
+// active search, including the matched field path of the selected instance.
+func (m instanceListModel) searchHeader() string {
+	if m.searching {
+		return m.searchInput.View()
+	}
+	query := m.searchInput.Value()
+	if query == "" {
+		return ""
+	}
+	summary := fmt.Sprintf("Search %q: %d of %d instances", query, len(m.matches), len(m.instances))
+	if cursor := m.table.Cursor(); cursor >= 0 && cursor < len(m.matches) {
+		summary += " · matched at " + schemaKeyStyle.Render(m.matches[cursor].path)
+	}
+	return HelpStyle.Margin(0).Render(summary)
+}
+
+// findValuePath walks an unstructured object and returns the path of the first
+// scalar value containing query. Keys are visited in sorted order so results are stable.
+func findValuePath(obj any, query, path string) (string, bool) {
+	switch v := obj.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			if path == "metadata" && k == "managedFields" {
+				continue
+			}
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			if p, ok := findValuePath(v[k], query, childPath); ok {
+				return p, true
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if p, ok := findValuePath(item, query, fmt.Sprintf("%s[%d]", path, i)); ok {
+				return p, true
 			}
 		}
-		if status == "" {
-			status = "Unknown"
+	case nil:
+		return "", false
+	default:
+		if strings.Contains(strings.ToLower(fmt.Sprint(v)), query) {
+			return path, true
 		}
-		ts, _, _ := unstructured.NestedString(inst.Object, "metadata", "creationTimestamp")
-		t, _ := time.Parse(time.RFC3339, ts)
-		rows[i] = table.Row{inst.GetName(), inst.GetNamespace(), status, k8s.HumanReadableAge(t)}
 	}
-	m.table.SetRows(rows)
+	return "", false
 }
 
 func (m *instanceListModel) buildSchemaTree() []*schemaNode {
@@ -495,6 +704,43 @@ func (m *instanceListModel) flattenSchema() {
 	}
 }
 
+// breadcrumb renders the field path of the schema node under the cursor.
+func (m instanceListModel) breadcrumb() string {
+	path := "spec"
+	if m.schemaCursor >= 0 && m.schemaCursor < len(m.flattenedSchema) {
+		path = schemaPath(m.flattenedSchema[m.schemaCursor])
+	}
+	crumb := MutedStyle.Render("Path: ") + schemaKeyStyle.Render(path)
+	// The copy result is shown until the cursor moves to another field.
+	if path == m.copiedPath {
+		if m.copyErr != nil {
+			crumb += "  " + ErrStyle.Render("copy failed: "+m.copyErr.Error())
+		} else {
+			crumb += "  " + MutedStyle.Render("✓ copied")
+		}
+	}
+	return crumb
+}
+
+// schemaPath returns the field path of a node by walking up its parents,
+// e.g. spec.template.spec.containers[].resources.
+func schemaPath(node *schemaNode) string {
+	var names []string
+	for n := node; n != nil; n = n.parent {
+		names = append(names, n.name)
+	}
+	var b strings.Builder
+	b.WriteString("spec")
+	for _, name := range slices.Backward(names) {
+		if name == "[items]" {
+			b.WriteString("[]")
+			continue
+		}
+		b.WriteString("." + name)
+	}
+	return b.String()
+}
+
 func (m *instanceListModel) getDepth(node *schemaNode) int {
 	depth := 0
 	for p := node.parent; p != nil; p = p.parent {
@@ -590,7 +836,7 @@ func (m *instanceListModel) updateViewportContent() {
 	}
 
 	// Finally, update the viewport content and adjust its scroll position.
-	m.viewport.SetContent(b.String())
+	m.viewport.SetContent(m.schemaSearch.apply(b.String()))
 	if m.viewport.Height > 0 && m.schemaCursor >= 0 && m.schemaCursor < len(m.flattenedSchema) {
 		selectedLayout := layouts[m.flattenedSchema[m.schemaCursor]]
 		if selectedLayout.startLine < m.viewport.YOffset {
@@ -1,6 +1,12 @@
 package tui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 // KeyMap defines the keybindings for the application.
 type KeyMap struct {
@@ -21,6 +27,26 @@ type KeyMap struct {
 	Tab      key.Binding
 	ShiftTab key.Binding
 	Expand   key.Binding
+	// Bookmark toggles the bookmark of the selected CRD, Bookmarks shows only bookmarked CRDs.
+	Bookmark  key.Binding
+	Bookmarks key.Binding
+	Recent    key.Binding
+	// Usage cycles the CRD list between all, only in-use and only unused CRDs.
+	Usage key.Binding
+	// NextMatch and PrevMatch jump between the matches of a viewport search.
+	NextMatch key.Binding
+	PrevMatch key.Binding
+	// CopyPath copies the field path of the selected schema node to the clipboard.
+	CopyPath key.Binding
+	// ToggleSearch turns the web search of AI analyses on and off for the session.
+	ToggleSearch key.Binding
+	// NextSection and PrevSection move between the YAML sections of the definition tab,
+	// FoldAll folds all of them or unfolds them when they are all folded.
+	NextSection key.Binding
+	PrevSection key.Binding
+	FoldAll     key.Binding
+	// ForceQuit quits from any view, including while a filter or modal has focus.
+	ForceQuit key.Binding
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view.
@@ -33,7 +59,10 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
 		{k.Enter, k.Back, k.Refresh, k.Quit},
-		{k.Analyze, k.Clusters, k.Filter, k.Info},
+		{k.Analyze, k.ToggleSearch, k.Clusters, k.Filter, k.Info},
+		{k.Bookmark, k.Bookmarks, k.Recent, k.Usage},
+		{k.Tab, k.Expand, k.NextMatch, k.PrevMatch, k.CopyPath},
+		{k.NextSection, k.PrevSection, k.FoldAll},
 	}
 }
 
@@ -68,6 +97,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("ctrl+c", "q"),
 			key.WithHelp("q", "quit"),
 		),
+		ForceQuit: key.NewBinding(
+			key.WithKeys("ctrl+c"),
+			key.WithHelp("ctrl+c", "quit from anywhere"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "toggle help"),
@@ -105,8 +138,111 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("shift+tab", "prev tab"),
 		),
 		Expand: key.NewBinding(
-			key.WithKeys("enter", "space"),
+			key.WithKeys("enter", " "),
 			key.WithHelp("enter/spc", "expand"),
 		),
+		Bookmark: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "bookmark"),
+		),
+		Bookmarks: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "bookmarks only"),
+		),
+		Recent: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "recent"),
+		),
+		Usage: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "in use/unused"),
+		),
+		NextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match"),
+		),
+		PrevMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "prev match"),
+		),
+		CopyPath: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy path"),
+		),
+		ToggleSearch: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "toggle AI web search"),
+		),
+		NextSection: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next section"),
+		),
+		PrevSection: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "prev section"),
+		),
+		FoldAll: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "fold all"),
+		),
+	}
+}
+
+// bindings returns the keybindings by the names used in the keybindings file.
+func (k *KeyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":           &k.Up,
+		"down":         &k.Down,
+		"left":         &k.Left,
+		"right":        &k.Right,
+		"enter":        &k.Enter,
+		"back":         &k.Back,
+		"quit":         &k.Quit,
+		"forceQuit":    &k.ForceQuit,
+		"help":         &k.Help,
+		"analyze":      &k.Analyze,
+		"clusters":     &k.Clusters,
+		"filter":       &k.Filter,
+		"refresh":      &k.Refresh,
+		"info":         &k.Info,
+		"cancel":       &k.Cancel,
+		"tab":          &k.Tab,
+		"shiftTab":     &k.ShiftTab,
+		"expand":       &k.Expand,
+		"bookmark":     &k.Bookmark,
+		"bookmarks":    &k.Bookmarks,
+		"recent":       &k.Recent,
+		"usage":        &k.Usage,
+		"nextMatch":    &k.NextMatch,
+		"prevMatch":    &k.PrevMatch,
+		"copyPath":     &k.CopyPath,
+		"toggleSearch": &k.ToggleSearch,
+		"nextSection":  &k.NextSection,
+		"prevSection":  &k.PrevSection,
+		"foldAll":      &k.FoldAll,
+	}
+}
+
+// Override replaces the keys of the named bindings. Bindings that are not named keep their keys.
+func (k *KeyMap) Override(overrides map[string][]string) error {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bindings := k.bindings()
+	for _, name := range names {
+		binding, ok := bindings[name]
+		if !ok {
+			return fmt.Errorf("unknown keybinding %q", name)
+		}
+		keys := overrides[name]
+		if len(keys) == 0 {
+			return fmt.Errorf("keybinding %q has no keys", name)
+		}
+		binding.SetKeys(keys...)
+		binding.SetHelp(strings.Join(keys, "/"), binding.Help().Desc)
 	}
+	return nil
 }
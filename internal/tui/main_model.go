@@ -23,10 +23,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/pehlicd/crd-wizard/internal/ai"
+	"github.com/pehlicd/crd-wizard/internal/config"
 	"github.com/pehlicd/crd-wizard/internal/k8s"
 	"github.com/pehlicd/crd-wizard/internal/models"
 )
@@ -38,11 +41,13 @@ const (
 	instanceListView
 	detailView
 	clusterSelectorView
+	recentView
 )
 
 type mainModel struct {
 	clusterManager    *k8s.ClusterManager
 	aiClient          *ai.Client
+	prefs             *config.TUI
 	view              currentView
 	err               error
 	width, height     int
@@ -52,20 +57,33 @@ type mainModel struct {
 	modalModel        modalModel
 	loadingMsg        string
 	analyzing         bool
-	showModal         bool
+	// pendingAnalysis is the estimate of an analysis waiting for the user to confirm it.
+	pendingAnalysis *aiEstimateMsg
+	// skipSearch leaves the web search out of AI analyses, toggled for the session.
+	skipSearch  bool
+	showModal   bool
+	showHelp    bool
+	showInfo    bool
+	clusterInfo models.ClusterInfo
+	keys        KeyMap
 	// Cluster selector state
 	clusterNames         []string
 	clusterSelectorIndex int
+	// Recent view state
+	recentItems []config.RecentItem
+	recentIndex int
 }
 
-func newMainModel(manager *k8s.ClusterManager, aiClient *ai.Client, crdName, kind string) mainModel {
+func newMainModel(manager *k8s.ClusterManager, aiClient *ai.Client, keys KeyMap, prefs *config.TUI, crdName, kind string) mainModel {
 	client := manager.GetCurrentClient()
 	model := mainModel{
 		clusterManager: manager,
 		aiClient:       aiClient,
+		prefs:          prefs,
 		view:           crdListView,
-		crdListModel:   newCRDListModel(client, nil),
+		crdListModel:   newCRDListModel(client, keys, prefs, nil),
 		clusterNames:   manager.ContextNames(),
+		keys:           keys,
 	}
 
 	// If a CRD name or Kind is provided via flags, fetch it and pre-filter crdList view
@@ -86,7 +104,7 @@ func newMainModel(manager *k8s.ClusterManager, aiClient *ai.Client, crdName, kin
 		}
 
 		if len(targetCRD) != 0 {
-			model.crdListModel = newCRDListModel(client, targetCRD)
+			model.crdListModel = newCRDListModel(client, keys, prefs, targetCRD)
 			return model
 		}
 	}
@@ -119,7 +137,7 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.showModal {
-			if msg.String() == "esc" {
+			if key.Matches(msg, m.keys.Cancel) {
 				m.showModal = false // Close modal
 				return m, nil
 			}
@@ -128,12 +146,60 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
-		if msg.String() == "ctrl+c" {
+		if key.Matches(msg, m.keys.ForceQuit) {
 			return m, tea.Quit
 		}
 
+		if m.pendingAnalysis != nil {
+			switch {
+			case key.Matches(msg, m.keys.Enter, m.keys.Analyze):
+				pending := m.pendingAnalysis
+				m.pendingAnalysis = nil
+				m.analyzing = true
+				m.loadingMsg = "Analyzing CRD with AI..."
+				return m, m.analyzeCRD(pending.target, pending.estimate.Prompt)
+			case key.Matches(msg, m.keys.ToggleSearch) && m.searchEnabled():
+				// The web results are part of the prompt, so its size has to be estimated again.
+				m.skipSearch = !m.skipSearch
+				m.pendingAnalysis = nil
+				m.analyzing = true
+				m.loadingMsg = "Estimating prompt size..."
+				return m, m.estimateSelectedCRD()
+			case key.Matches(msg, m.keys.Back, m.keys.Cancel, m.keys.Quit):
+				m.pendingAnalysis = nil
+			}
+			return m, nil
+		}
+
+		if m.showHelp {
+			if key.Matches(msg, m.keys.Help, m.keys.Cancel, m.keys.Quit) {
+				m.showHelp = false
+			}
+			return m, nil
+		}
+		if m.showInfo {
+			if key.Matches(msg, m.keys.Info, m.keys.Back, m.keys.Cancel, m.keys.Quit) {
+				m.showInfo = false
+			}
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.Help) && !m.analyzing && !m.inputFocused() {
+			m.showHelp = true
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.Info) && !m.analyzing && !m.inputFocused() {
+			client := m.clusterManager.GetCurrentClient()
+			return m, func() tea.Msg {
+				clusterInfo, err := client.GetClusterInfo()
+				if err != nil {
+					return errMsg{err}
+				}
+				return showInfoMsg{clusterInfo}
+			}
+		}
+
 		// AI Analysis Trigger
-		if msg.String() == "a" {
+		if key.Matches(msg, m.keys.Analyze) {
 			if m.view != crdListView {
 				// Ignore if not in list view
 				return m, nil
@@ -148,13 +214,27 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			m.analyzing = true
-			m.loadingMsg = "Analyzing CRD with AI..."
-			return m, m.analyzeSelectedCRD()
+			m.loadingMsg = "Estimating prompt size..."
+			return m, m.estimateSelectedCRD()
+		}
+
+		// Web search toggle for AI analyses (only from crdListView)
+		if key.Matches(msg, m.keys.ToggleSearch) {
+			if m.view == crdListView && !m.analyzing && !m.showModal && !m.crdListFiltering() && m.aiClient != nil {
+				m.analyzing = true
+				if m.searchEnabled() {
+					m.skipSearch = !m.skipSearch
+					m.loadingMsg = "🔍 Web search for AI analysis: " + m.searchState()
+				} else {
+					m.loadingMsg = "🔍 Web search is disabled.\nRun with --enable-search"
+				}
+				return m, tea.Tick(2*time.Second, func(_ time.Time) tea.Msg { return clearErrorMsg{} })
+			}
 		}
 
 		// Cluster Selector Trigger (only from crdListView)
-		if msg.String() == "c" {
-			if m.view == crdListView && !m.analyzing && !m.showModal {
+		if key.Matches(msg, m.keys.Clusters) {
+			if m.view == crdListView && !m.analyzing && !m.showModal && !m.crdListFiltering() {
 				// Find current cluster index
 				currentName := m.clusterManager.GetCurrentContextName()
 				for i, name := range m.clusterNames {
@@ -168,14 +248,57 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Recent View Trigger (only from crdListView)
+		if key.Matches(msg, m.keys.Recent) {
+			if m.view == crdListView && !m.analyzing && !m.showModal && !m.crdListFiltering() {
+				m.recentItems = m.prefs.RecentFor(m.clusterManager.GetCurrentContextName())
+				m.recentIndex = 0
+				m.view = recentView
+				return m, nil
+			}
+		}
+
+	case tea.MouseMsg:
+		// Overlays take the mouse so clicks do not reach the view underneath.
+		if m.showModal {
+			m.modalModel, cmd = m.modalModel.Update(msg)
+			return m, cmd
+		}
+		if m.showHelp || m.showInfo || m.analyzing || m.pendingAnalysis != nil {
+			return m, nil
+		}
+
+	case showInfoMsg:
+		m.clusterInfo = msg.ClusterInfo
+		m.showInfo = true
+		return m, nil
+
+	case switchClusterMsg:
+		if err := m.clusterManager.SetCurrentContext(msg.name); err != nil {
+			m.view = crdListView
+			return m, func() tea.Msg { return errMsg{fmt.Errorf("failed to switch cluster: %w", err)} }
+		}
+		// Drop every view built against the previous cluster so none of its data lingers.
+		m.instanceListModel = nil
+		m.detailViewModel = nil
+		m.crdListModel = newCRDListModel(m.clusterManager.GetCurrentClient(), m.keys, m.prefs, nil)
+		// Send window size to the new model so it renders correctly
+		m.crdListModel, _ = m.crdListModel.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+		m.view = crdListView
+		return m, m.crdListModel.Init()
+
 	case showInstancesMsg:
-		m.instanceListModel = newInstanceListModel(m.clusterManager.GetCurrentClient(), msg.crd, m.width, m.height)
-		cmds = append(cmds, m.instanceListModel.Init())
+		m.instanceListModel = newInstanceListModel(m.clusterManager.GetCurrentClient(), m.keys, msg.crd, m.width, m.height)
+		cmds = append(cmds, m.instanceListModel.Init(), m.addRecent(config.RecentItem{CRD: msg.crd.Name}))
 		m.view = instanceListView
 
 	case showDetailsMsg:
-		m.detailViewModel = newDetailModel(m.clusterManager.GetCurrentClient(), msg.crd, msg.instance, m.width, m.height)
-		cmds = append(cmds, m.detailViewModel.Init())
+		m.detailViewModel = newDetailModel(m.clusterManager.GetCurrentClient(), m.keys, msg.crd, msg.instance, m.width, m.height)
+		cmds = append(cmds, m.detailViewModel.Init(), m.addRecent(config.RecentItem{
+			CRD:       msg.crd.Name,
+			Namespace: msg.instance.GetNamespace(),
+			Name:      msg.instance.GetName(),
+		}))
 		m.view = detailView
 
 	case goBackMsg:
@@ -190,6 +313,17 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.view = instanceListView
 		}
 
+	case aiEstimateMsg:
+		m.analyzing = false
+		// Cached documentation costs nothing, so there is nothing to confirm.
+		if msg.estimate.Cached {
+			m.analyzing = true
+			m.loadingMsg = "Analyzing CRD with AI..."
+			return m, m.analyzeCRD(msg.target, "")
+		}
+		m.pendingAnalysis = &msg
+		return m, nil
+
 	case aiResultMsg:
 		m.modalModel = newModalModel("AI Analysis", msg.content, m.width, m.height)
 		m.analyzing = false
@@ -221,27 +355,44 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case clusterSelectorView:
 		// Handle cluster selector navigation
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			switch keyMsg.String() {
-			case "up", "k":
+			switch {
+			case key.Matches(keyMsg, m.keys.Up):
 				if m.clusterSelectorIndex > 0 {
 					m.clusterSelectorIndex--
 				}
-			case "down", "j":
+			case key.Matches(keyMsg, m.keys.Down):
 				if m.clusterSelectorIndex < len(m.clusterNames)-1 {
 					m.clusterSelectorIndex++
 				}
-			case "enter":
+			case key.Matches(keyMsg, m.keys.Enter):
+				if len(m.clusterNames) == 0 {
+					return m, nil
+				}
 				// Switch to selected cluster
 				selectedCluster := m.clusterNames[m.clusterSelectorIndex]
-				if err := m.clusterManager.SetCurrentContext(selectedCluster); err == nil {
-					// Reinitialize crdListModel with new client
-					m.crdListModel = newCRDListModel(m.clusterManager.GetCurrentClient(), nil)
-					// Send window size to the new model so it renders correctly
-					m.crdListModel, _ = m.crdListModel.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
-					m.view = crdListView
-					return m, m.crdListModel.Init()
+				return m, func() tea.Msg { return switchClusterMsg{name: selectedCluster} }
+			case key.Matches(keyMsg, m.keys.Back, m.keys.Quit, m.keys.Clusters):
+				m.view = crdListView
+				return m, nil
+			}
+		}
+	case recentView:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			switch {
+			case key.Matches(keyMsg, m.keys.Up):
+				if m.recentIndex > 0 {
+					m.recentIndex--
+				}
+			case key.Matches(keyMsg, m.keys.Down):
+				if m.recentIndex < len(m.recentItems)-1 {
+					m.recentIndex++
+				}
+			case key.Matches(keyMsg, m.keys.Enter):
+				if len(m.recentItems) == 0 {
+					return m, nil
 				}
-			case "esc", "q":
+				return m, m.openRecent(m.recentItems[m.recentIndex])
+			case key.Matches(keyMsg, m.keys.Back, m.keys.Quit, m.keys.Recent):
 				m.view = crdListView
 				return m, nil
 			}
@@ -263,6 +414,8 @@ func (m mainModel) View() string {
 		baseView = m.detailViewModel.View()
 	case clusterSelectorView:
 		baseView = m.renderClusterSelector()
+	case recentView:
+		baseView = m.renderRecent()
 	default:
 		baseView = "Unknown view"
 	}
@@ -276,11 +429,23 @@ func (m mainModel) View() string {
 		return overlay(baseView, loadingBox, m.width, m.height)
 	}
 
+	if m.pendingAnalysis != nil {
+		return overlay(baseView, m.renderEstimate(), m.width, m.height)
+	}
+
 	if m.showModal {
 		// Overlay Modal
 		return overlay(baseView, m.modalModel.View(), m.width, m.height)
 	}
 
+	if m.showHelp {
+		return overlay(baseView, m.renderHelp(), m.width, m.height)
+	}
+
+	if m.showInfo {
+		return overlay(baseView, m.renderInfo(), m.width, m.height)
+	}
+
 	return baseView
 }
 
@@ -348,65 +513,238 @@ type aiResultMsg struct {
 
 type clearErrorMsg struct{}
 
-func (m mainModel) analyzeSelectedCRD() tea.Cmd {
+// aiTarget is the CRD version an AI analysis is run for.
+type aiTarget struct {
+	group, version, kind, schemaJSON string
+}
+
+// aiEstimateMsg carries the estimated size and cost of analyzing target, which the user
+// confirms before the analysis runs.
+type aiEstimateMsg struct {
+	target   aiTarget
+	estimate *ai.Estimate
+}
+
+// selectedAITarget fetches the full CRD selected in the list and picks the version to analyze.
+func (m mainModel) selectedAITarget() (aiTarget, error) {
+	// Hack to get selected item. In a real world, we'd refactor crdListModel to expose it cleanly.
+	listModel, ok := m.crdListModel.(crdListModel)
+	if !ok {
+		return aiTarget{}, fmt.Errorf("could not get selected CRD")
+	}
+	selected := listModel.SelectedItem()
+	if selected == nil {
+		return aiTarget{}, fmt.Errorf("could not get selected CRD")
+	}
+
+	// The model likely only has summary.
+	// We need to fetch the Full CRD.
+	fullCRD, err := m.clusterManager.GetCurrentClient().GetFullCRD(context.Background(), selected.Name)
+	if err != nil {
+		return aiTarget{}, err
+	}
+
+	// Extract Schema (simplified)
+	// We need to find the version matching the one we are interested in, usually the storage version or the first one.
+	var schemaJSON string
+	version := ""
+	if len(fullCRD.Spec.Versions) > 0 {
+		version = fullCRD.Spec.Versions[0].Name // Default to first
+		for _, v := range fullCRD.Spec.Versions {
+			if v.Storage {
+				version = v.Name
+				break
+			}
+		}
+
+		if fullCRD.Spec.Versions[0].Schema != nil && fullCRD.Spec.Versions[0].Schema.OpenAPIV3Schema != nil {
+			b, err := json.Marshal(fullCRD.Spec.Versions[0].Schema.OpenAPIV3Schema)
+			if err == nil {
+				schemaJSON = string(b)
+			}
+		}
+	}
+
+	if schemaJSON == "" {
+		schemaJSON = "{}" // Fallback if no schema found or error
+	}
+	if version == "" {
+		return aiTarget{}, fmt.Errorf("no version found for CRD %s", selected.Name)
+	}
+	return aiTarget{group: selected.Group, version: version, kind: selected.Kind, schemaJSON: schemaJSON}, nil
+}
+
+// estimateSelectedCRD estimates the prompt size and cost of analyzing the selected CRD.
+func (m mainModel) estimateSelectedCRD() tea.Cmd {
 	return func() tea.Msg {
-		// Hack to get selected item. In a real world, we'd refactor crdListModel to expose it cleanly.
-		// For now, let's assume `crdListModel` is our internal `crdListModel` struct and assert it.
-		// NOTE: Check crdlist_model.go to see if strict/public access is available.
-		// If not, we might need to fetch the selection index.
-
-		// If we can't easily get it, let's just use a dummy for this step or try to fix it.
-		// Let's assume we can cast.
-		if listModel, ok := m.crdListModel.(crdListModel); ok {
-			if selected := listModel.SelectedItem(); selected != nil {
-				// We have the CRD.
-				var schemaJSON string
-				// The model likely only has summary.
-				// We need to fetch the Full CRD.
-				fullCRD, err := m.clusterManager.GetCurrentClient().GetFullCRD(context.Background(), selected.Name)
-				if err != nil {
-					return errMsg{err}
-				}
+		target, err := m.selectedAITarget()
+		if err != nil {
+			return errMsg{err}
+		}
 
-				// Extract Schema (simplified)
-				// We need to find the version matching the one we are interested in, usually the storage version or the first one.
-				version := ""
-				if len(fullCRD.Spec.Versions) > 0 {
-					version = fullCRD.Spec.Versions[0].Name // Default to first
-					for _, v := range fullCRD.Spec.Versions {
-						if v.Storage {
-							version = v.Name
-							break
-						}
-					}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
 
-					if fullCRD.Spec.Versions[0].Schema != nil && fullCRD.Spec.Versions[0].Schema.OpenAPIV3Schema != nil {
-						b, err := json.Marshal(fullCRD.Spec.Versions[0].Schema.OpenAPIV3Schema)
-						if err == nil {
-							schemaJSON = string(b)
-						}
-					}
-				}
+		estimate, err := m.aiClient.EstimateCrdContext(ctx, target.group, target.version, target.kind, target.schemaJSON, m.generateOptions())
+		if err != nil {
+			return errMsg{err}
+		}
+		return aiEstimateMsg{target: target, estimate: estimate}
+	}
+}
 
-				if schemaJSON == "" {
-					schemaJSON = "{}" // Fallback if no schema found or error
-				}
-				if version == "" {
-					return errMsg{fmt.Errorf("no version found for CRD %s", selected.Name)}
-				}
+// analyzeCRD runs the AI analysis of target, sending prompt when it was already built for the
+// estimate the user confirmed.
+func (m mainModel) analyzeCRD(target aiTarget, prompt string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		opts := m.generateOptions()
+		opts.Prompt = prompt
+		res, err := m.aiClient.GenerateCrdContext(ctx, target.group, target.version, target.kind, target.schemaJSON, opts)
+		if err != nil {
+			return errMsg{err}
+		}
+		return aiResultMsg{res}
+	}
+}
 
-				ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-				defer cancel()
+// searchEnabled reports whether AI analyses may search the web, which --enable-search decides.
+func (m mainModel) searchEnabled() bool {
+	return m.aiClient != nil && m.aiClient.Config.EnableSearch
+}
 
-				res, err := m.aiClient.GenerateCrdContext(ctx, selected.Group, version, selected.Kind, schemaJSON)
-				if err != nil {
-					return errMsg{err}
-				}
-				return aiResultMsg{res}
+// searchState describes whether the next AI analysis searches the web.
+func (m mainModel) searchState() string {
+	if m.searchEnabled() && !m.skipSearch {
+		return "on"
+	}
+	return "off"
+}
+
+func (m mainModel) generateOptions() ai.GenerateOptions {
+	return ai.GenerateOptions{SkipSearch: m.skipSearch}
+}
+
+// renderEstimate renders the estimate of a pending analysis for the user to confirm.
+func (m mainModel) renderEstimate() string {
+	estimate := m.pendingAnalysis.estimate
+	rows := []string{
+		TitleStyle.Render("🤖 AI Analysis"),
+		"",
+		fmt.Sprintf("%s %s (%s)", MutedStyle.Render("Target:  "), m.pendingAnalysis.target.kind, m.pendingAnalysis.target.version),
+		fmt.Sprintf("%s %s / %s", MutedStyle.Render("Model:   "), estimate.Provider, estimate.Model),
+		fmt.Sprintf("%s ~%d tokens", MutedStyle.Render("Prompt:  "), estimate.PromptTokens),
+		fmt.Sprintf("%s %s", MutedStyle.Render("Search:  "), m.searchState()),
+	}
+	if estimate.MaxOutputTokens > 0 {
+		rows = append(rows, fmt.Sprintf("%s up to %d tokens", MutedStyle.Render("Response:"), estimate.MaxOutputTokens))
+	}
+	if estimate.CostUSD != nil {
+		rows = append(rows, fmt.Sprintf("%s ~$%.4f per attempt", MutedStyle.Render("Cost:    "), *estimate.CostUSD))
+	}
+	for _, warning := range estimate.Warnings {
+		rows = append(rows, "", WarnStyle.Width(60).Render("⚠ "+warning))
+	}
+	actions := "[Enter/a] Analyze  [Esc] Cancel"
+	if m.searchEnabled() {
+		actions = "[Enter/a] Analyze  [s] Toggle search  [Esc] Cancel"
+	}
+	rows = append(rows, "", MutedStyle.Render(actions))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#874BFD")).
+		Padding(1, 3).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// crdListFiltering reports whether the CRD list is capturing keys for its filter input.
+func (m mainModel) crdListFiltering() bool {
+	listModel, ok := m.crdListModel.(crdListModel)
+	return ok && listModel.filtering
+}
+
+// inputFocused reports whether the active view is capturing keys for a text input.
+func (m mainModel) inputFocused() bool {
+	switch m.view {
+	case crdListView:
+		return m.crdListFiltering()
+	case instanceListView:
+		listModel, ok := m.instanceListModel.(instanceListModel)
+		return ok && (listModel.searching || listModel.schemaSearch.typing)
+	case detailView:
+		detail, ok := m.detailViewModel.(detailModel)
+		return ok && detail.search.typing
+	}
+	return false
+}
+
+// renderHelp renders the full keybinding help shown by the help overlay.
+func (m mainModel) renderHelp() string {
+	h := help.New()
+	h.ShowAll = true
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		TitleStyle.Render("⌨ Keybindings"),
+		"",
+		h.View(m.keys),
+		"",
+		MutedStyle.Render("[?/Esc] Close"),
+	)
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#874BFD")).
+		Padding(1, 3).
+		Render(content)
+}
+
+// renderInfo renders the cluster information shown by the info overlay.
+func (m mainModel) renderInfo() string {
+	client := m.clusterManager.GetCurrentClient()
+	rows := [][2]string{
+		{"Context", m.clusterManager.GetCurrentContextName()},
+		{"Cluster", m.clusterInfo.ClusterName},
+		{"K8s Version", m.clusterInfo.ServerVersion},
+		{"CRDs", fmt.Sprintf("%d", m.clusterInfo.NumCRDs)},
+	}
+	// Instance counts are only known once the CRD list has loaded them.
+	if listModel, ok := m.crdListModel.(crdListModel); ok && !listModel.loading {
+		instances, withInstances := 0, 0
+		for _, crd := range listModel.crds {
+			instances += crd.InstanceCount
+			if crd.InstanceCount > 0 {
+				withInstances++
 			}
 		}
-		return errMsg{fmt.Errorf("could not get selected CRD")}
+		rows = append(rows, [2]string{"Instances", fmt.Sprintf("%d in %d CRDs", instances, withInstances)})
+	}
+	rows = append(rows, [2]string{"Contexts", fmt.Sprintf("%d in kubeconfig", m.clusterManager.ContextCount())})
+	if client.ReadOnly() {
+		rows = append(rows, [2]string{"Mode", "read-only"})
 	}
+	if m.aiClient != nil {
+		rows = append(rows, [2]string{"AI", fmt.Sprintf("%s (%s)", m.aiClient.Config.Provider, m.aiClient.Config.Model)})
+	} else {
+		rows = append(rows, [2]string{"AI", "disabled"})
+	}
+
+	var b strings.Builder
+	for _, row := range rows {
+		b.WriteString(fmt.Sprintf("%s %s\n", MutedStyle.Render(fmt.Sprintf("%-12s", row[0]+":")), row[1]))
+	}
+	for _, warning := range m.clusterInfo.Warnings {
+		b.WriteString("\n" + WarnStyle.Width(60).Render("⚠ "+warning) + "\n")
+	}
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		TitleStyle.Render("Cluster Information"),
+		"",
+		strings.TrimSuffix(b.String(), "\n"),
+		"",
+		MutedStyle.Render("[i/Esc] Close"),
+	)
+	// Context names can be long, so let the modal grow instead of wrapping them.
+	return ModalStyle.UnsetWidth().Render(content)
 }
 
 // renderClusterSelector renders the cluster selection view
@@ -448,3 +786,83 @@ func (m mainModel) renderClusterSelector() string {
 	content := AppStyle.Render(b.String())
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
 }
+
+// addRecent records a viewed CRD or instance of the current cluster and persists the recent list.
+func (m mainModel) addRecent(item config.RecentItem) tea.Cmd {
+	item.Cluster = m.clusterManager.GetCurrentContextName()
+	m.prefs.AddRecent(item)
+	if err := m.prefs.Save(); err != nil {
+		return func() tea.Msg { return errMsg{err} }
+	}
+	return nil
+}
+
+// openRecent navigates back to a recently viewed CRD or instance.
+func (m mainModel) openRecent(item config.RecentItem) tea.Cmd {
+	var crd *models.CRD
+	if listModel, ok := m.crdListModel.(crdListModel); ok {
+		for i := range listModel.crds {
+			if listModel.crds[i].Name == item.CRD {
+				crd = &listModel.crds[i]
+				break
+			}
+		}
+	}
+	if crd == nil {
+		return func() tea.Msg { return errMsg{fmt.Errorf("CRD %s not found in the current cluster", item.CRD)} }
+	}
+
+	selected := *crd
+	showInstances := func() tea.Msg { return showInstancesMsg{crd: selected} }
+	if item.Name == "" {
+		return showInstances
+	}
+
+	client := m.clusterManager.GetCurrentClient()
+	// The instance list is opened first so that going back from the details works as usual.
+	return tea.Sequence(showInstances, func() tea.Msg {
+		instance, err := client.GetSingleCR(context.Background(), item.CRD, item.Namespace, item.Name)
+		if err != nil {
+			return errMsg{err}
+		}
+		return showDetailsMsg{crd: selected, instance: *instance}
+	})
+}
+
+// renderRecent renders the list of recently viewed CRDs and instances of the current cluster.
+func (m mainModel) renderRecent() string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render("🕘 Recently Viewed"))
+	b.WriteString("\n\n")
+
+	if len(m.recentItems) == 0 {
+		b.WriteString("Nothing viewed in this cluster yet.\n")
+	}
+
+	for i, item := range m.recentItems {
+		cursor := "  "
+		style := lipgloss.NewStyle()
+		if i == m.recentIndex {
+			cursor = "▶ "
+			style = SelectedStyle
+		}
+
+		line := item.CRD
+		if item.Name != "" {
+			name := item.Name
+			if item.Namespace != "" {
+				name = item.Namespace + "/" + name
+			}
+			line = fmt.Sprintf("%s  %s", name, MutedStyle.Render(item.CRD))
+		}
+		b.WriteString(style.Render(cursor + line))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render("[↑/↓] Navigate | [Enter] Open | [Esc] Cancel"))
+
+	content := AppStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
@@ -38,5 +38,8 @@ type fullCRDLoadedMsg struct {
 type crdsLoadedMsg struct{ crds []models.CRD }
 type showInfoMsg struct{ models.ClusterInfo }
 
+// switchClusterMsg asks the main model to make the named kubeconfig context the active cluster.
+type switchClusterMsg struct{ name string }
+
 type goBackMsg struct{}
 type errMsg struct{ err error }
@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+// isLeftClick reports whether the mouse event is a press of the left button.
+func isLeftClick(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+}
+
+// tableRowAt returns the index of the table row rendered at line y of the table view,
+// or -1 when there is no row at that line. The table does not expose how far it is
+// scrolled, so the rendered line is matched against the rows around the cursor.
+func tableRowAt(t table.Model, y int) int {
+	view := strings.Split(ansi.Strip(t.View()), "\n")
+	headerHeight := len(view) - t.Height()
+	if y < headerHeight || y >= len(view) {
+		return -1
+	}
+	line := strings.TrimSpace(view[y])
+	if line == "" {
+		return -1
+	}
+
+	rows, cols := t.Rows(), t.Columns()
+	// Only rows within one table height of the cursor can be visible.
+	first, last := max(t.Cursor()-t.Height(), 0), min(t.Cursor()+t.Height(), len(rows)-1)
+	for i := first; i <= last; i++ {
+		if strings.HasPrefix(plainRow(rows[i], cols), line) {
+			return i
+		}
+	}
+	return -1
+}
+
+// plainRow renders a table row without styling the way the table lays it out,
+// with every cell padded by one space on each side like CellStyle does.
+func plainRow(row table.Row, cols []table.Column) string {
+	var b strings.Builder
+	for i, value := range row {
+		if i >= len(cols) || cols[i].Width <= 0 {
+			continue
+		}
+		cell := runewidth.Truncate(value, cols[i].Width, "…")
+		b.WriteString(" " + cell + strings.Repeat(" ", max(cols[i].Width-runewidth.StringWidth(cell), 0)) + " ")
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,180 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	searchMatchStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#282A36")).Background(lipgloss.Color("#F1FA8C"))
+	currentMatchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#282A36")).Background(lipgloss.Color("#FFB86C")).Bold(true)
+)
+
+// viewportSearch finds text in the content of a viewport, highlights the matching
+// lines and scrolls between them, like the search of a pager.
+type viewportSearch struct {
+	input textinput.Model
+	// typing is set while the query is being entered.
+	typing bool
+	query  string
+	// matches holds the numbers of the content lines containing the query.
+	matches []int
+	current int
+}
+
+func newViewportSearch() viewportSearch {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "Search..."
+	ti.CharLimit = 156
+	return viewportSearch{input: ti}
+}
+
+// start begins entering a new query.
+func (s *viewportSearch) start() tea.Cmd {
+	s.typing = true
+	s.input.SetValue(s.query)
+	return s.input.Focus()
+}
+
+// update handles a key while the query is being entered and reports whether the
+// query has changed so the content needs to be searched again.
+func (s *viewportSearch) update(msg tea.KeyMsg, keys KeyMap) (bool, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Enter):
+		s.typing = false
+		s.input.Blur()
+		return false, nil
+	case key.Matches(msg, keys.Cancel):
+		s.typing = false
+		s.input.Blur()
+		s.clear()
+		return true, nil
+	}
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	s.query = s.input.Value()
+	s.current = 0
+	return true, cmd
+}
+
+// clear drops the query and its matches.
+func (s *viewportSearch) clear() {
+	s.query = ""
+	s.matches = nil
+	s.current = 0
+	s.input.SetValue("")
+}
+
+// active reports whether a query is being entered or applied.
+func (s viewportSearch) active() bool {
+	return s.typing || s.query != ""
+}
+
+// apply finds the query in content and returns the content with the matches highlighted.
+// Matching lines lose their own styling so that the highlight stays readable.
+func (s *viewportSearch) apply(content string) string {
+	s.matches = s.matches[:0]
+	if s.query == "" {
+		return content
+	}
+
+	query := strings.ToLower(s.query)
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		plain := ansi.Strip(line)
+		if !strings.Contains(strings.ToLower(plain), query) {
+			continue
+		}
+		s.matches = append(s.matches, i)
+		lines[i] = highlightMatches(plain, query, len(s.matches)-1 == s.current)
+	}
+	if s.current >= len(s.matches) {
+		s.current = 0
+	}
+	return strings.Join(lines, "\n")
+}
+
+// highlightMatches styles every case-insensitive occurrence of query in line.
+func highlightMatches(line, query string, current bool) string {
+	style := searchMatchStyle
+	if current {
+		style = currentMatchStyle
+	}
+
+	lower := strings.ToLower(line)
+	if len(lower) != len(line) {
+		// Lowercasing changed the byte offsets, so mark the whole line instead.
+		return style.Render(line)
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			b.WriteString(line)
+			return b.String()
+		}
+		b.WriteString(line[:i])
+		b.WriteString(style.Render(line[i : i+len(query)]))
+		line, lower = line[i+len(query):], lower[i+len(query):]
+	}
+}
+
+// step moves to the next (step > 0) or previous (step < 0) match, wrapping around.
+// It reports whether there is a match to move to.
+func (s *viewportSearch) step(step int) bool {
+	if len(s.matches) == 0 {
+		return false
+	}
+	s.current = (s.current + step + len(s.matches)) % len(s.matches)
+	return true
+}
+
+// reveal scrolls the viewport so that the current match is visible.
+func (s viewportSearch) reveal(vp *viewport.Model) {
+	if s.current >= len(s.matches) {
+		return
+	}
+	line := s.matches[s.current]
+	if line < vp.YOffset || line >= vp.YOffset+vp.Height {
+		vp.SetYOffset(line - vp.Height/2)
+	}
+}
+
+// View renders the query input while typing and the match position afterwards.
+func (s viewportSearch) View() string {
+	if s.typing {
+		return s.input.View()
+	}
+	if s.query == "" {
+		return ""
+	}
+	if len(s.matches) == 0 {
+		return fmt.Sprintf("/%s: no matches · [Esc] Clear", s.query)
+	}
+	return fmt.Sprintf("/%s: match %d of %d · [n/N] Next/Prev · [Esc] Clear", s.query, s.current+1, len(s.matches))
+}
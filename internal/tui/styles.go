@@ -16,22 +16,63 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 */
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 var (
 	AppStyle         = lipgloss.NewStyle().Margin(1, 2).Border(lipgloss.HiddenBorder(), true).BorderForeground(lipgloss.Color("#7D56F4"))
 	TitleStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")).Bold(true).Align(lipgloss.Top)
 	HelpStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Margin(1, 0).Align(lipgloss.Bottom)
+	MutedStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 	ErrStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87")).Bold(true)
+	WarnStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
 	HeaderStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Bold(true).Padding(0, 1).Border(lipgloss.NormalBorder(), false, false, true, false).BorderForeground(lipgloss.Color("#7D56F4"))
 	CellStyle        = lipgloss.NewStyle().Padding(0, 1)
 	SelectedStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F8F8F2")).Background(lipgloss.Color("#7D56F4"))
 	TabStyle         = lipgloss.NewStyle().Padding(0, 1).MarginRight(2)
 	ActiveTabStyle   = TabStyle.Foreground(lipgloss.Color("#F8F8F2")).Background(lipgloss.Color("#7D56F4")).Bold(true)
 	InactiveTabStyle = TabStyle.Foreground(lipgloss.Color("241"))
+	ClusterTagStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#F8F8F2")).Background(lipgloss.Color("#0E7490")).Padding(0, 1)
 	ModalStyle       = lipgloss.NewStyle().
 				BorderStyle(lipgloss.RoundedBorder()).
 				BorderForeground(lipgloss.Color("#874BFD")).
 				Padding(1, 3).
 				Width(40)
+
+	freshAgeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+	staleAgeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 )
+
+// ClusterTag renders the name of the cluster the views are connected to.
+func ClusterTag(name string) string {
+	if name == "" {
+		return ""
+	}
+	return ClusterTagStyle.Render("⎈ " + name)
+}
+
+// ReadOnlyTag marks views whose client refuses mutating requests.
+func ReadOnlyTag(readOnly bool) string {
+	if !readOnly {
+		return ""
+	}
+	return WarnStyle.Padding(0, 1).Render("read-only")
+}
+
+// AgeStyle colors ages by recency: anything from the last hour stands out,
+// anything older than a week fades into the background.
+func AgeStyle(t time.Time) lipgloss.Style {
+	switch d := time.Since(t); {
+	case t.IsZero():
+		return staleAgeStyle
+	case d < time.Hour:
+		return freshAgeStyle
+	case d > 7*24*time.Hour:
+		return staleAgeStyle
+	default:
+		return lipgloss.NewStyle()
+	}
+}
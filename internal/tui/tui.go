@@ -17,15 +17,35 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package tui
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/pehlicd/crd-wizard/internal/ai"
+	"github.com/pehlicd/crd-wizard/internal/config"
 	"github.com/pehlicd/crd-wizard/internal/k8s"
+	"github.com/pehlicd/crd-wizard/internal/logger"
 )
 
-// Start initializes and runs the TUI program.
-func Start(manager *k8s.ClusterManager, aiClient *ai.Client, crdName, kind string) error {
-	p := tea.NewProgram(newMainModel(manager, aiClient, crdName, kind), tea.WithAltScreen())
-	_, err := p.Run()
+// Start initializes and runs the TUI program. Problems with the saved TUI state are logged
+// to log before the TUI takes over the terminal.
+func Start(manager *k8s.ClusterManager, aiClient *ai.Client, crdName, kind string, log *logger.Logger) error {
+	prefs, err := config.LoadTUI()
+	if err != nil {
+		// Bookmarks and recent items are a convenience, not worth refusing to start over.
+		log.Warn("ignoring saved TUI state, bookmarks and recent items are not kept this session", "err", err)
+	}
+
+	overrides, err := config.LoadKeybindings()
+	if err != nil {
+		return fmt.Errorf("failed to load keybindings: %w", err)
+	}
+	keys := DefaultKeyMap()
+	if err := keys.Override(overrides); err != nil {
+		return fmt.Errorf("invalid keybindings: %w", err)
+	}
+
+	p := tea.NewProgram(newMainModel(manager, aiClient, keys, prefs, crdName, kind), tea.WithAltScreen(), tea.WithMouseCellMotion())
+	_, err = p.Run()
 	return err
 }
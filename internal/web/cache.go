@@ -0,0 +1,95 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package web
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/pehlicd/crd-wizard/internal/k8s"
+)
+
+// instanceCountCache remembers per-CRD instance counts so listing CRDs does not
+// re-scan the whole cluster on every request. Expired counts are still served
+// while a single background refresh brings them up to date.
+type instanceCountCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]*instanceCountEntry
+	// sem bounds the number of CountCRDInstances calls, including background
+	// refreshes, running against the API servers at once.
+	sem chan struct{}
+}
+
+type instanceCountEntry struct {
+	count      int
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+func newInstanceCountCache(ttl time.Duration, concurrency int) *instanceCountCache {
+	return &instanceCountCache{
+		ttl:     ttl,
+		entries: make(map[string]*instanceCountEntry),
+		sem:     make(chan struct{}, concurrency),
+	}
+}
+
+// Count returns the number of instances of crd in the client's cluster.
+// A zero TTL disables caching.
+func (c *instanceCountCache) Count(client *k8s.Client, crd apiextensionsv1.CustomResourceDefinition) int {
+	if c.ttl <= 0 {
+		return c.count(client, crd)
+	}
+
+	key := client.ClusterName + "/" + crd.Name
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		count := c.count(client, crd)
+		c.store(key, count)
+		return count
+	}
+
+	count := entry.count
+	if time.Since(entry.fetchedAt) >= c.ttl && !entry.refreshing {
+		entry.refreshing = true
+		go func() {
+			c.store(key, c.count(client, crd))
+		}()
+	}
+	c.mu.Unlock()
+	return count
+}
+
+// count lists the instances of crd once a concurrency slot is free.
+func (c *instanceCountCache) count(client *k8s.Client, crd apiextensionsv1.CustomResourceDefinition) int {
+	c.sem <- struct{}{}
+	defer func() { <-c.sem }()
+	return client.CountCRDInstances(context.Background(), crd)
+}
+
+func (c *instanceCountCache) store(key string, count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = &instanceCountEntry{count: count, fetchedAt: time.Now()}
+}
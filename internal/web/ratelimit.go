@@ -0,0 +1,102 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package web
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// idleLimiterTTL is how long the limiter of a client that stopped sending requests is kept.
+const idleLimiterTTL = 10 * time.Minute
+
+// ipRateLimiter hands out a token bucket per client IP.
+type ipRateLimiter struct {
+	limit    rate.Limit
+	burst    int
+	mu       sync.Mutex
+	limiters map[string]*ipLimiter
+}
+
+type ipLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// newIPRateLimiter allows perMinute requests per minute for each client IP.
+func newIPRateLimiter(perMinute int) *ipRateLimiter {
+	return &ipRateLimiter{
+		limit:    rate.Limit(float64(perMinute) / 60),
+		burst:    perMinute,
+		limiters: make(map[string]*ipLimiter),
+	}
+}
+
+func (l *ipRateLimiter) allow(ip string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range l.limiters {
+		if now.Sub(entry.lastSeen) > idleLimiterTTL {
+			delete(l.limiters, key)
+		}
+	}
+
+	entry, ok := l.limiters[ip]
+	if !ok {
+		entry = &ipLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[ip] = entry
+	}
+	entry.lastSeen = now
+
+	reservation := entry.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// rateLimit rejects requests with 429 Too Many Requests once the client IP exceeds its limit.
+func (s *Server) rateLimit(limiter *ipRateLimiter, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// CORS preflights are not counted against the limit.
+		if r.Method == http.MethodOptions {
+			next(w, r)
+			return
+		}
+
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		if ok, retryAfter := limiter.allow(ip); !ok {
+			s.log.Warn("rate limit exceeded", "ip", ip, "path", r.URL.Path)
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			s.respondWithJSON(w, r, http.StatusTooManyRequests, map[string]string{"error": "Too many requests, please try again later"})
+			return
+		}
+		next(w, r)
+	}
+}
@@ -18,19 +18,29 @@ package web
 
 import (
 	"archive/zip"
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"io/fs"
+	"net"
 	"net/http"
+	"net/netip"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/yaml"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
 
 	"github.com/pehlicd/crd-wizard/internal/ai"
 	"github.com/pehlicd/crd-wizard/internal/generator"
@@ -43,55 +53,204 @@ import (
 //go:embed static/*
 var staticFiles embed.FS
 
+// Options holds the tunables of the web server.
+type Options struct {
+	// ExportConcurrency limits how many CRDs are fetched and rendered at once by ExportAllHandler.
+	ExportConcurrency int
+	// AllowPrivateFetch lets GenerateHandler fetch URLs that resolve to private,
+	// loopback or link-local addresses.
+	AllowPrivateFetch bool
+	// FetchAllow lists address prefixes that GenerateHandler and webhooks may reach even
+	// when AllowPrivateFetch is unset, e.g. an internal Git server.
+	FetchAllow []netip.Prefix
+	// FetchDeny lists address prefixes that GenerateHandler and webhooks never reach.
+	FetchDeny []netip.Prefix
+	// InstanceCountTTL is how long per-CRD instance counts are reused by CrdsHandler
+	// before being refreshed in the background. Zero disables the cache.
+	InstanceCountTTL time.Duration
+	// CountConcurrency limits how many CRDs have their instances counted at once.
+	CountConcurrency int
+	// AIRateLimit is the number of AI requests per minute allowed for each client IP.
+	// Zero disables rate limiting.
+	AIRateLimit int
+	// OnListen is called in its own goroutine once the listener is bound, with the
+	// address it is bound to.
+	OnListen func(addr net.Addr)
+	// UnixSocket makes the server listen on the Unix domain socket at this path
+	// instead of TCP. The socket file is removed when the server stops.
+	UnixSocket string
+	// ReadTimeout, WriteTimeout and IdleTimeout configure the underlying http.Server.
+	// Zero values fall back to the defaults below.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// LongRequestTimeout replaces WriteTimeout for AI generation and export routes,
+	// which can take minutes to respond.
+	LongRequestTimeout time.Duration
+	// Build identifies the running build, served by /api/version and /api/status.
+	Build models.BuildInfo
+	// WebhookToken, when set, is the bearer token required to register or remove webhooks
+	// through the API.
+	WebhookToken string
+}
+
+// Default timeouts of the web server, see Options.
+const (
+	DefaultReadTimeout        = 30 * time.Second
+	DefaultWriteTimeout       = time.Minute
+	DefaultIdleTimeout        = 2 * time.Minute
+	DefaultLongRequestTimeout = 15 * time.Minute
+
+	// readHeaderTimeout guards against clients that trickle request headers (slowloris).
+	readHeaderTimeout = 10 * time.Second
+)
+
+// shutdownTimeout bounds how long Start waits for in-flight requests once its context is done.
+const shutdownTimeout = 10 * time.Second
+
 type Server struct {
 	ClusterManager *k8s.ClusterManager
 	router         *http.ServeMux
 	server         *http.Server
 	aiClient       *ai.Client
+	opts           Options
 	log            *logger.Logger
 	startTime      time.Time
+	instanceCounts *instanceCountCache
+	// stopCh stops the informers started by the server.
+	stopCh   chan struct{}
+	webhooks *webhookRegistry
 }
 
-func NewServer(clusterManager *k8s.ClusterManager, port string, aiClient *ai.Client, log *logger.Logger) *Server {
+func NewServer(clusterManager *k8s.ClusterManager, host, port string, aiClient *ai.Client, opts Options, log *logger.Logger) *Server {
+	if opts.ExportConcurrency < 1 {
+		opts.ExportConcurrency = 5
+	}
+	if opts.CountConcurrency < 1 {
+		opts.CountConcurrency = 10
+	}
+	if opts.ReadTimeout <= 0 {
+		opts.ReadTimeout = DefaultReadTimeout
+	}
+	if opts.WriteTimeout <= 0 {
+		opts.WriteTimeout = DefaultWriteTimeout
+	}
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = DefaultIdleTimeout
+	}
+	if opts.LongRequestTimeout <= 0 {
+		opts.LongRequestTimeout = DefaultLongRequestTimeout
+	}
+
 	r := http.NewServeMux()
 	s := &Server{
 		ClusterManager: clusterManager,
 		router:         r,
 		server: &http.Server{
-			Addr:         ":" + port,
-			Handler:      r,
-			ReadTimeout:  15 * time.Minute,
-			WriteTimeout: 15 * time.Minute,
-			IdleTimeout:  15 * time.Minute,
+			Addr:              net.JoinHostPort(host, port),
+			Handler:           r,
+			ReadHeaderTimeout: readHeaderTimeout,
+			ReadTimeout:       opts.ReadTimeout,
+			WriteTimeout:      opts.WriteTimeout,
+			IdleTimeout:       opts.IdleTimeout,
 		},
-		aiClient:  aiClient,
-		log:       log,
-		startTime: time.Now(),
+		aiClient:       aiClient,
+		opts:           opts,
+		log:            log,
+		startTime:      time.Now(),
+		instanceCounts: newInstanceCountCache(opts.InstanceCountTTL, opts.CountConcurrency),
+		stopCh:         make(chan struct{}),
+		webhooks:       newWebhookRegistry(),
 	}
+	// Warm the CRD cache of the default cluster, other clusters start theirs on first use.
+	clusterManager.GetCurrentClient().StartCRDInformer(s.stopCh)
 	s.registerHandlers()
 	return s
 }
 
-func (s *Server) Start() error {
-	return s.server.ListenAndServe()
+// Start serves the API until ctx is done, then shuts the server down gracefully.
+func (s *Server) Start(ctx context.Context) error {
+	defer close(s.stopCh)
+	ln, err := s.listen()
+	if err != nil {
+		return err
+	}
+	if s.opts.OnListen != nil {
+		go s.opts.OnListen(ln.Addr())
+	}
+
+	// Serve closes the listener when it returns, which also unlinks a Unix socket file.
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- s.server.Serve(ln) }()
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return s.server.Shutdown(shutdownCtx)
+}
+
+// listen creates the listener configured by the options, a Unix socket or TCP on the server address.
+func (s *Server) listen() (net.Listener, error) {
+	if s.opts.UnixSocket == "" {
+		return net.Listen("tcp", s.server.Addr)
+	}
+	// A socket file left behind by a crashed server would make Listen fail.
+	if info, err := os.Stat(s.opts.UnixSocket); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", s.opts.UnixSocket)
+		}
+		if err := os.Remove(s.opts.UnixSocket); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", s.opts.UnixSocket, err)
+		}
+	}
+	return net.Listen("unix", s.opts.UnixSocket)
+}
+
+// longRunning extends the write deadline of a request to Options.LongRequestTimeout
+// for handlers that may legitimately take longer than the server-wide WriteTimeout.
+func (s *Server) longRunning(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(s.opts.LongRequestTimeout)); err != nil {
+			s.log.Warn("unable to extend write deadline", "path", r.URL.Path, "err", err)
+		}
+		next(w, r)
+	}
 }
 
 func (s *Server) registerHandlers() {
 	apiRouter := s.router
 	apiRouter.HandleFunc("/clusters", s.ClustersHandler)
 	apiRouter.HandleFunc("/cluster-info", s.ClusterInfoHandler)
+	apiRouter.HandleFunc("/namespaces", s.NamespacesHandler)
 	apiRouter.HandleFunc("/crds", s.CrdsHandler)
+	apiRouter.HandleFunc("/crds/unused", s.longRunning(s.CrdsUnusedHandler))
 	apiRouter.HandleFunc("/crs", s.CrsHandler)
+	apiRouter.HandleFunc("/crs/summary", s.CrsSummaryHandler)
+	apiRouter.HandleFunc("/crs/stats", s.CrsStatsHandler)
+	apiRouter.HandleFunc("/crs/orphaned", s.CrsOrphanedHandler)
 	apiRouter.HandleFunc("/cr", s.CrHandler)
+	apiRouter.HandleFunc("/cr/preview", s.CrPreviewHandler)
+	apiRouter.HandleFunc("/crd/examples", s.CrdExamplesHandler)
+	apiRouter.HandleFunc("/crd/versions", s.CrdVersionsHandler)
 	apiRouter.HandleFunc("/events", s.EventsHandler)
 	apiRouter.HandleFunc("/resource-graph", s.ResourceGraphHandler)
 	if s.aiClient != nil {
-		apiRouter.HandleFunc("/crd/generate-context", s.GenerateCrdContextHandler)
+		handler := s.GenerateCrdContextHandler
+		if s.opts.AIRateLimit > 0 {
+			handler = s.rateLimit(newIPRateLimiter(s.opts.AIRateLimit), handler)
+		}
+		apiRouter.HandleFunc("/crd/generate-context", s.longRunning(handler))
 	}
+	apiRouter.HandleFunc("/webhooks", s.WebhooksHandler)
 	apiRouter.HandleFunc("/status", s.Status)
-	apiRouter.HandleFunc("/export", s.ExportHandler)
-	apiRouter.HandleFunc("/export-all", s.ExportAllHandler)
-	apiRouter.HandleFunc("/generate", s.GenerateHandler)
+	apiRouter.HandleFunc("/version", s.VersionHandler)
+	apiRouter.HandleFunc("/export", s.longRunning(s.ExportHandler))
+	apiRouter.HandleFunc("/export-all", s.longRunning(s.ExportAllHandler))
+	apiRouter.HandleFunc("/generate", s.longRunning(s.GenerateHandler))
 	s.router.Handle("/api/", http.StripPrefix("/api", s.log.Middleware(apiRouter)))
 
 	// Health endpoint is registered without logging middleware to avoid noise in logs
@@ -140,16 +299,23 @@ func serveStaticFiles(staticFS http.FileSystem, w http.ResponseWriter, r *http.R
 }
 
 type statusResponse struct {
+	Version   string `json:"version"`
 	Uptime    string `json:"uptime"`
 	AIEnabled bool   `json:"aiEnabled"`
 }
 
-func (s *Server) Status(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) Status(w http.ResponseWriter, r *http.Request) {
 	resp := statusResponse{
+		Version:   s.opts.Build.Version,
 		Uptime:    time.Since(s.startTime).String(),
 		AIEnabled: s.aiClient != nil,
 	}
-	s.respondWithJSON(w, http.StatusOK, resp)
+	s.respondWithJSON(w, r, http.StatusOK, resp)
+}
+
+// VersionHandler returns the version, build date and commit of the running server.
+func (s *Server) VersionHandler(w http.ResponseWriter, r *http.Request) {
+	s.respondWithJSON(w, r, http.StatusOK, s.opts.Build)
 }
 
 // generateContextRequest defines the expected JSON body for the AI context generation endpoint.
@@ -174,7 +340,7 @@ func (s *Server) GenerateCrdContextHandler(w http.ResponseWriter, r *http.Reques
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 
 	if r.Method != http.MethodPost {
-		s.respondWithJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "Only POST method is allowed"})
+		s.respondWithJSON(w, r, http.StatusMethodNotAllowed, map[string]string{"error": "Only POST method is allowed"})
 		return
 	}
 
@@ -185,12 +351,37 @@ func (s *Server) GenerateCrdContextHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	generatedText, err := s.aiClient.GenerateCrdContext(
+	// ?search=false skips the web search for this request; search can only be turned off
+	// here, it stays disabled when --enable-search is off.
+	var opts ai.GenerateOptions
+	if search := r.URL.Query().Get("search"); search != "" {
+		enabled, err := strconv.ParseBool(search)
+		if err != nil {
+			http.Error(w, "Bad Request: invalid search parameter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		opts.SkipSearch = !enabled
+	}
+
+	// An estimate builds the prompt without sending it, so users can check its size and cost first.
+	if estimate, _ := strconv.ParseBool(r.URL.Query().Get("estimate")); estimate {
+		result, err := s.aiClient.EstimateCrdContext(r.Context(), reqPayload.Group, reqPayload.Version, reqPayload.Kind, reqPayload.SchemaJSON, opts)
+		if err != nil {
+			s.log.Error("error estimating crd context generation", "err", err)
+			http.Error(w, "Error building AI prompt: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		s.respondWithJSON(w, r, http.StatusOK, result)
+		return
+	}
+
+	result, err := s.aiClient.GenerateCrdContextVerbose(
 		r.Context(),
 		reqPayload.Group,
 		reqPayload.Version,
 		reqPayload.Kind,
 		reqPayload.SchemaJSON,
+		opts,
 	)
 	if err != nil {
 		s.log.Error("error generating crd context from ollama", "err", err)
@@ -198,10 +389,16 @@ func (s *Server) GenerateCrdContextHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// In verbose mode the content is returned along with the validation history.
+	if verbose, _ := strconv.ParseBool(r.URL.Query().Get("verbose")); verbose {
+		s.respondWithJSON(w, r, http.StatusOK, result)
+		return
+	}
+
 	// If success, just return the content as text strings
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(generatedText))
+	_, _ = w.Write([]byte(result.Content))
 }
 
 // getClientForRequest returns the appropriate K8s client based on the X-Cluster-Name header.
@@ -215,9 +412,9 @@ func (s *Server) getClientForRequest(r *http.Request) (*k8s.Client, error) {
 }
 
 // ClustersHandler returns a list of all available clusters.
-func (s *Server) ClustersHandler(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) ClustersHandler(w http.ResponseWriter, r *http.Request) {
 	clusters := s.ClusterManager.ListClusters()
-	s.respondWithJSON(w, http.StatusOK, clusters)
+	s.respondWithJSON(w, r, http.StatusOK, clusters)
 }
 
 func (s *Server) ClusterInfoHandler(w http.ResponseWriter, r *http.Request) {
@@ -235,7 +432,90 @@ func (s *Server) ClusterInfoHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.respondWithJSON(w, http.StatusOK, clusterInfo)
+	s.respondWithJSON(w, r, http.StatusOK, clusterInfo)
+}
+
+// NamespacesHandler returns the names of all namespaces in the cluster.
+func (s *Server) NamespacesHandler(w http.ResponseWriter, r *http.Request) {
+	client, err := s.getClientForRequest(r)
+	if err != nil {
+		s.log.Error("cluster not found", "err", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	namespaces, err := client.GetNamespaces(r.Context())
+	if err != nil {
+		s.log.Error("error listing namespaces", "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	s.respondWithJSON(w, r, http.StatusOK, namespaces)
+}
+
+// maxExampleLimit bounds the number of live instances returned by CrdExamplesHandler.
+const maxExampleLimit = 50
+
+// CrdExamplesHandler returns cleaned live instances of a CRD as examples, without involving the AI provider.
+// The response is a JSON array of objects, or a YAML stream when format=yaml is given.
+// An optional namespace parameter restricts the examples to a single namespace.
+func (s *Server) CrdExamplesHandler(w http.ResponseWriter, r *http.Request) {
+	client, err := s.getClientForRequest(r)
+	if err != nil {
+		s.log.Error("cluster not found", "err", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	group, version, kind := query.Get("group"), query.Get("version"), query.Get("kind")
+	if version == "" || kind == "" {
+		http.Error(w, "version and kind query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	limit := k8s.DefaultExampleLimit
+	if raw := query.Get("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxExampleLimit {
+			http.Error(w, fmt.Sprintf("limit must be a number between 1 and %d", maxExampleLimit), http.StatusBadRequest)
+			return
+		}
+	}
+
+	items, err := client.ListCRDExamples(r.Context(), group, version, kind, k8s.ExampleOptions{
+		Limit:     int64(limit),
+		Namespace: query.Get("namespace"),
+	})
+	if err != nil {
+		s.log.Error("error fetching crd examples", "group", group, "version", version, "kind", kind, "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	if query.Get("format") == "yaml" {
+		docs := make([]string, 0, len(items))
+		for _, item := range items {
+			out, err := yaml.Marshal(item.Object)
+			if err != nil {
+				s.log.Error("error marshalling example to yaml", "name", item.GetName(), "err", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			docs = append(docs, string(out))
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(strings.Join(docs, "---\n")))
+		return
+	}
+
+	examples := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		examples = append(examples, item.Object)
+	}
+	s.respondWithJSON(w, r, http.StatusOK, examples)
 }
 
 func (s *Server) CrdsHandler(w http.ResponseWriter, r *http.Request) {
@@ -247,28 +527,84 @@ func (s *Server) CrdsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Note: This re-uses the k8s.GetCRDs which returns the TUI model.
-	// For the API, we want the full spec, so we fetch the raw list and convert.
-	crdList, err := client.ExtensionsClient.ApiextensionsV1().CustomResourceDefinitions().List(context.Background(), metav1.ListOptions{})
+	// For the API, we want the full spec, so we read the raw list from the informer cache and convert.
+	client.StartCRDInformer(s.stopCh)
+	crds, err := client.ListCRDsCached(r.Context())
 	if err != nil {
 		s.log.Error("error listing CRDs", "err", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 
-	apiCrds := make([]models.APICRD, len(crdList.Items))
+	// The ETag only covers the definitions. Instance counts change far more often and
+	// are refreshed whenever the definitions change or the client skips revalidation.
+	etag := crdListETag(crds)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Access-Control-Expose-Headers", "ETag")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	apiCrds := make([]models.APICRD, len(crds))
+	// Cached counts return immediately, the cache bounds how many are listed at once.
 	var wg sync.WaitGroup
-	for i, crd := range crdList.Items {
+	for i, crd := range crds {
 		wg.Add(1)
 		go func(i int, crd apiextensionsv1.CustomResourceDefinition) {
 			defer wg.Done()
-			// This is a bit inefficient as it recounts, but for correctness with the new model.
-			instanceCount := client.CountCRDInstances(context.Background(), crd)
+			instanceCount := s.instanceCounts.Count(client, crd)
 			apiCrds[i] = models.ToAPICRD(crd, instanceCount)
 		}(i, crd)
 	}
 	wg.Wait()
 
-	s.respondWithJSON(w, http.StatusOK, apiCrds)
+	s.respondWithJSON(w, r, http.StatusOK, apiCrds)
+}
+
+// CrdsUnusedHandler lists the CRDs without any instances, candidates for removing the
+// operator that installed them. Unlike CrdsHandler it does not use cached instance counts,
+// so that a CRD is never reported unused on stale data.
+func (s *Server) CrdsUnusedHandler(w http.ResponseWriter, r *http.Request) {
+	client, err := s.getClientForRequest(r)
+	if err != nil {
+		s.log.Error("cluster not found", "err", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := client.UnusedCRDs(r.Context(), s.opts.CountConcurrency)
+	if err != nil {
+		s.log.Error("error finding unused CRDs", "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	s.respondWithJSON(w, r, http.StatusOK, report)
+}
+
+// crdListETag derives a weak ETag from the names and resource versions of the CRDs.
+func crdListETag(crds []apiextensionsv1.CustomResourceDefinition) string {
+	versions := make([]string, 0, len(crds))
+	for _, crd := range crds {
+		versions = append(versions, crd.Name+"@"+crd.ResourceVersion)
+	}
+	slices.Sort(versions)
+
+	sum := sha256.Sum256([]byte(strings.Join(versions, ",")))
+	return fmt.Sprintf(`W/"%x"`, sum[:16])
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *Server) CrsHandler(w http.ResponseWriter, r *http.Request) {
@@ -293,7 +629,100 @@ func (s *Server) CrsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.respondWithJSON(w, http.StatusOK, crs)
+	s.respondWithJSON(w, r, http.StatusOK, crs)
+}
+
+// CrsSummaryHandler returns a compact name/namespace/status/age listing of the instances of a CRD.
+func (s *Server) CrsSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	client, err := s.getClientForRequest(r)
+	if err != nil {
+		s.log.Error("cluster not found", "err", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	crdName := r.URL.Query().Get("crdName")
+	if crdName == "" {
+		s.log.Error("crd name is empty")
+		http.Error(w, "crdName query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	crs, err := client.GetCRsForCRD(r.Context(), crdName)
+	if err != nil {
+		s.log.Error("error getting crs from wizard api", "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	summaries := make([]models.InstanceSummary, len(crs))
+	for i, cr := range crs {
+		summaries[i] = k8s.SummarizeInstance(cr)
+	}
+	s.respondWithJSON(w, r, http.StatusOK, summaries)
+}
+
+// CrsStatsHandler returns the number of instances of a CRD grouped by their derived status.
+func (s *Server) CrsStatsHandler(w http.ResponseWriter, r *http.Request) {
+	client, err := s.getClientForRequest(r)
+	if err != nil {
+		s.log.Error("cluster not found", "err", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	crdName := r.URL.Query().Get("crdName")
+	if crdName == "" {
+		s.log.Error("crd name is empty")
+		http.Error(w, "crdName query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	crs, err := client.GetCRsForCRD(r.Context(), crdName)
+	if err != nil {
+		s.log.Error("error getting crs from wizard api", "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	s.respondWithJSON(w, r, http.StatusOK, k8s.InstanceStatusStats(crs))
+}
+
+// CrsOrphanedHandler returns the instances of a CRD that nothing owns and whose status has
+// not been updated for ?staleAfter= (a duration, 7 days by default), which suggests their
+// controller is gone.
+func (s *Server) CrsOrphanedHandler(w http.ResponseWriter, r *http.Request) {
+	client, err := s.getClientForRequest(r)
+	if err != nil {
+		s.log.Error("cluster not found", "err", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	crdName := r.URL.Query().Get("crdName")
+	if crdName == "" {
+		s.log.Error("crd name is empty")
+		http.Error(w, "crdName query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	staleAfter := k8s.DefaultOrphanStaleAfter
+	if value := r.URL.Query().Get("staleAfter"); value != "" {
+		staleAfter, err = time.ParseDuration(value)
+		if err != nil || staleAfter < 0 {
+			http.Error(w, "staleAfter must be a non-negative duration, e.g. 72h", http.StatusBadRequest)
+			return
+		}
+	}
+
+	crs, err := client.GetCRsForCRD(r.Context(), crdName)
+	if err != nil {
+		s.log.Error("error getting crs from wizard api", "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	s.respondWithJSON(w, r, http.StatusOK, k8s.OrphanedInstances(crs, staleAfter, time.Now()))
 }
 
 func (s *Server) CrHandler(w http.ResponseWriter, r *http.Request) {
@@ -320,7 +749,129 @@ func (s *Server) CrHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
-	s.respondWithJSON(w, http.StatusOK, cr)
+
+	// Expose the humanized age alongside the exact timestamp so clients don't re-implement age math.
+	created := cr.GetCreationTimestamp().Time
+	w.Header().Set("X-Resource-Age", k8s.HumanReadableAge(created))
+	w.Header().Set("X-Resource-Created-At", k8s.Timestamp(created))
+	w.Header().Set("Access-Control-Expose-Headers", "X-Resource-Age, X-Resource-Created-At")
+	s.respondWithJSON(w, r, http.StatusOK, cr)
+}
+
+// maxPatchSize bounds the request body accepted by CrPreviewHandler.
+const maxPatchSize = 1 << 20
+
+// crPreview is the response of CrPreviewHandler.
+type crPreview struct {
+	Original *unstructured.Unstructured `json:"original"`
+	Patched  *unstructured.Unstructured `json:"patched"`
+	// Validated is set when the patch passed a server-side dry-run.
+	Validated       bool   `json:"validated"`
+	ValidationError string `json:"validationError,omitempty"`
+}
+
+// CrPreviewHandler applies a JSON patch or JSON merge patch to a custom resource without
+// persisting it and returns the resource before and after the patch. With dryRun set,
+// the patch is also validated by the API server.
+func (s *Server) CrPreviewHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	client, err := s.getClientForRequest(r)
+	if err != nil {
+		s.log.Error("cluster not found", "err", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		CrdName   string          `json:"crdName"`
+		Namespace string          `json:"namespace"`
+		Name      string          `json:"name"`
+		Patch     json.RawMessage `json:"patch"`
+		// PatchType is "json" (RFC 6902, the default) or "merge" (RFC 7386).
+		PatchType string `json:"patchType"`
+		DryRun    bool   `json:"dryRun"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPatchSize)).Decode(&req); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+	if req.CrdName == "" || req.Name == "" || len(req.Patch) == 0 {
+		http.Error(w, "crdName, name and patch are required", http.StatusBadRequest)
+		return
+	}
+
+	var patchType types.PatchType
+	switch req.PatchType {
+	case "", "json":
+		patchType = types.JSONPatchType
+	case "merge":
+		patchType = types.MergePatchType
+	default:
+		http.Error(w, fmt.Sprintf("unsupported patchType %q, use json or merge", req.PatchType), http.StatusBadRequest)
+		return
+	}
+
+	original, patched, err := client.PreviewCRPatch(r.Context(), req.CrdName, req.Namespace, req.Name, patchType, req.Patch)
+	if err != nil {
+		switch {
+		case errors.Is(err, k8s.ErrInvalidPatch):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case apierrors.IsNotFound(err):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			s.log.Error("error previewing cr patch", "crd", req.CrdName, "name", req.Name, "err", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	preview := crPreview{Original: original, Patched: patched}
+	if req.DryRun {
+		validated, err := client.DryRunCRPatch(r.Context(), req.CrdName, req.Namespace, req.Name, patchType, req.Patch)
+		if err != nil {
+			preview.ValidationError = err.Error()
+		} else {
+			// The server-side result includes defaulting applied by the API server.
+			preview.Patched = validated
+			preview.Validated = true
+		}
+	}
+
+	s.respondWithJSON(w, r, http.StatusOK, preview)
+}
+
+// CrdVersionsHandler returns the served, storage and deprecation state of every version of a CRD.
+func (s *Server) CrdVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	client, err := s.getClientForRequest(r)
+	if err != nil {
+		s.log.Error("cluster not found", "err", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	crdName := r.URL.Query().Get("crdName")
+	if crdName == "" {
+		s.log.Error("crd name is empty")
+		http.Error(w, "crdName query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	crd, err := client.GetFullCRD(r.Context(), crdName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("CRD %s not found", crdName), http.StatusNotFound)
+			return
+		}
+		s.log.Error("error getting crd", "crd", crdName, "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	s.respondWithJSON(w, r, http.StatusOK, models.CRDVersions(*crd))
 }
 
 func (s *Server) EventsHandler(w http.ResponseWriter, r *http.Request) {
@@ -346,7 +897,7 @@ func (s *Server) EventsHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
-	s.respondWithJSON(w, http.StatusOK, events)
+	s.respondWithJSON(w, r, http.StatusOK, events)
 }
 
 func (s *Server) ResourceGraphHandler(w http.ResponseWriter, r *http.Request) {
@@ -364,17 +915,40 @@ func (s *Server) ResourceGraphHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	graph, err := client.GetResourceGraph(context.Background(), uid)
+	// The kind of the resource, when given, lets a graph restricted to some kinds still find it quickly.
+	// Without it the kind is looked up among the custom resources, which is still cheaper than
+	// the scan of the whole cluster a restricted graph falls back to when it misses the resource.
+	var opts k8s.GraphOptions
+	kind := r.URL.Query().Get("kind")
+	if kind == "" && client.RestrictsGraphKinds() {
+		if kind, err = client.CustomResourceKind(r.Context(), uid); err != nil {
+			s.log.Warn("could not look up the kind of the resource, the graph may scan the whole cluster", "uid", uid, "err", err)
+		}
+	}
+	if kind != "" {
+		opts.Kinds = []string{kind}
+	}
+	// The scan can be narrowed to custom resources or to some API groups to make it cheaper.
+	opts.CustomResourcesOnly = r.URL.Query().Get("customOnly") == "true"
+	if groups := r.URL.Query().Get("groups"); groups != "" {
+		opts.Groups = strings.Split(groups, ",")
+	}
+	// The scan is aborted when the client disconnects.
+	graph, err := client.GetResourceGraphWithOptions(r.Context(), uid, opts)
 	if err != nil {
+		if r.Context().Err() != nil {
+			s.log.Debug("resource graph request cancelled", "uid", uid)
+			return
+		}
 		s.log.Error("error getting resource graph from wizard api", "uid", uid, "err", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
-	s.respondWithJSON(w, http.StatusOK, graph)
+	s.respondWithJSON(w, r, http.StatusOK, graph)
 }
 
 func (s *Server) HealthHandler(w http.ResponseWriter, r *http.Request) {
-	clusterCount := s.ClusterManager.ClusterCount()
+	contextCount := s.ClusterManager.ContextCount()
 
 	client, err := s.getClientForRequest(r)
 	if err != nil {
@@ -389,26 +963,32 @@ func (s *Server) HealthHandler(w http.ResponseWriter, r *http.Request) {
 		s.log.Error("health check failed", "err", err)
 		status = models.Health{
 			Status:       models.StatusUnhealthy.String(),
-			ClusterCount: clusterCount,
+			ClusterCount: contextCount,
 			Message:      err.Error(),
 		}
-		s.respondWithJSON(w, http.StatusServiceUnavailable, status)
+		s.respondWithJSON(w, r, http.StatusServiceUnavailable, status)
 		return
 	}
 
 	status = models.Health{
 		Status:       models.StatusHealthy.String(),
-		ClusterCount: clusterCount,
+		ClusterCount: contextCount,
 	}
-	s.respondWithJSON(w, http.StatusOK, status)
+	s.respondWithJSON(w, r, http.StatusOK, status)
 }
 
-func (s *Server) respondWithJSON(w http.ResponseWriter, code int, payload any) {
+// respondWithJSON writes payload as JSON. It is indented when the request asks for it
+// with ?pretty=true, which makes responses readable when calling the API with curl.
+func (s *Server) respondWithJSON(w http.ResponseWriter, r *http.Request, code int, payload any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.WriteHeader(code)
 	if payload != nil {
-		if err := json.NewEncoder(w).Encode(payload); err != nil {
+		encoder := json.NewEncoder(w)
+		if pretty, _ := strconv.ParseBool(r.URL.Query().Get("pretty")); pretty {
+			encoder.SetIndent("", "  ")
+		}
+		if err := encoder.Encode(payload); err != nil {
 			s.log.Error("Failed to encode JSON response", "err", err)
 		}
 	}
@@ -443,7 +1023,7 @@ func (s *Server) ExportHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	gen := generator.NewGenerator()
+	gen := generator.NewGenerator(generator.Options{})
 	apiCRD := models.ToAPICRD(*crd, 0)
 	content, err := gen.Generate(apiCRD, format)
 	if err != nil {
@@ -453,11 +1033,7 @@ func (s *Server) ExportHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Set headers for download
-	contentType := "text/html"
-	if format == "markdown" || format == "md" {
-		contentType = "text/markdown"
-	}
-	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Type", contentType(format))
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.%s\"", crdName, getExtension(format)))
 	_, _ = w.Write(content)
 }
@@ -476,10 +1052,20 @@ func (s *Server) ExportAllHandler(w http.ResponseWriter, r *http.Request) {
 		format = "html"
 	}
 
-	s.log.Info("exporting all CRDs", "format", format, "cluster", client.ClusterName)
+	// ?group= (repeated or comma-separated) and ?selector= export a subset like the CLI flags.
+	var filter k8s.CRDFilter
+	for _, groups := range r.URL.Query()["group"] {
+		filter.Groups = append(filter.Groups, strings.Split(groups, ",")...)
+	}
+	filter.Selector = r.URL.Query().Get("selector")
+
+	s.log.Info("exporting all CRDs", "format", format, "cluster", client.ClusterName, "groups", filter.Groups, "selector", filter.Selector)
 
-	// List all CRDs
-	crdList, err := client.ExtensionsClient.ApiextensionsV1().CustomResourceDefinitions().List(context.Background(), metav1.ListOptions{})
+	crds, err := client.ListCRDsFiltered(context.Background(), filter)
+	if errors.Is(err, k8s.ErrInvalidSelector) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		s.log.Error("failed to list CRDs", "err", err)
 		http.Error(w, "Failed to list CRDs: "+err.Error(), http.StatusInternalServerError)
@@ -494,16 +1080,25 @@ func (s *Server) ExportAllHandler(w http.ResponseWriter, r *http.Request) {
 	defer zipWriter.Close()
 
 	// Concurrency control
-	concurrencyLimit := 5
-	semaphore := make(chan struct{}, concurrencyLimit)
+	semaphore := make(chan struct{}, s.opts.ExportConcurrency)
 	var wg sync.WaitGroup
 
 	// Mutex to synchronize zip writes (zip.Writer is not thread-safe)
 	var zipMutex sync.Mutex
 
-	gen := generator.NewGenerator()
+	gen := generator.NewGenerator(generator.Options{})
 
-	for _, crdItem := range crdList.Items {
+	// Every exported document is listed in the index, every CRD that could not be exported
+	// in _skipped.txt, both guarded by zipMutex.
+	var index []generator.IndexEntry
+	var skipped []skippedCRD
+	skip := func(name string, err error) {
+		zipMutex.Lock()
+		defer zipMutex.Unlock()
+		skipped = append(skipped, skippedCRD{name: name, reason: err.Error()})
+	}
+
+	for _, crdItem := range crds {
 		wg.Add(1)
 		semaphore <- struct{}{} // Acquire token
 
@@ -515,6 +1110,7 @@ func (s *Server) ExportAllHandler(w http.ResponseWriter, r *http.Request) {
 			crd, err := client.GetFullCRD(r.Context(), name)
 			if err != nil {
 				s.log.Error("failed to get CRD", "name", name, "err", err)
+				skip(name, err)
 				return // Skip this CRD on error
 			}
 
@@ -522,6 +1118,7 @@ func (s *Server) ExportAllHandler(w http.ResponseWriter, r *http.Request) {
 			content, err := gen.Generate(apiCRD, format)
 			if err != nil {
 				s.log.Error("failed to generate documentation", "name", name, "err", err)
+				skip(name, err)
 				return
 			}
 
@@ -534,16 +1131,75 @@ func (s *Server) ExportAllHandler(w http.ResponseWriter, r *http.Request) {
 			f, err := zipWriter.Create(fileName)
 			if err != nil {
 				s.log.Error("failed to create zip entry", "name", fileName, "err", err)
+				skipped = append(skipped, skippedCRD{name: name, reason: err.Error()})
 				return
 			}
 			if _, err := f.Write(content); err != nil {
 				s.log.Error("failed to write zip entry content", "name", fileName, "err", err)
+				skipped = append(skipped, skippedCRD{name: name, reason: err.Error()})
+				return
 			}
+			index = append(index, generator.IndexEntry{Name: name, Kind: apiCRD.Spec.Names.Kind, Group: apiCRD.Spec.Group, File: fileName})
 
 		}(crdItem.Name)
 	}
 
 	wg.Wait()
+	s.log.Info("exported all CRDs", "cluster", client.ClusterName, "exported", len(index), "skipped", len(skipped))
+
+	// The skipped CRDs are listed first, so the archive tells what is missing even if the index fails.
+	if len(skipped) > 0 {
+		f, err := zipWriter.Create(skippedFileName)
+		if err != nil {
+			s.log.Error("failed to create zip entry", "name", skippedFileName, "err", err)
+			return
+		}
+		if _, err := f.Write(skippedReport(skipped)); err != nil {
+			s.log.Error("failed to write zip entry content", "name", skippedFileName, "err", err)
+			return
+		}
+	}
+
+	content, err := gen.Index(index, format)
+	if err != nil {
+		s.log.Error("failed to generate index", "err", err)
+		return
+	}
+	indexName := fmt.Sprintf("index.%s", getExtension(format))
+	f, err := zipWriter.Create(indexName)
+	if err != nil {
+		s.log.Error("failed to create zip entry", "name", indexName, "err", err)
+		return
+	}
+	if _, err := f.Write(content); err != nil {
+		s.log.Error("failed to write zip entry content", "name", indexName, "err", err)
+	}
+}
+
+// skippedFileName is the entry of the export ZIP that lists the CRDs that could not be exported.
+const skippedFileName = "_skipped.txt"
+
+// skippedCRD is a CRD left out of an export, with the reason why.
+type skippedCRD struct {
+	name, reason string
+}
+
+// skippedReport lists the skipped CRDs sorted by name, one per line with its reason.
+func skippedReport(skipped []skippedCRD) []byte {
+	slices.SortFunc(skipped, func(a, b skippedCRD) int { return strings.Compare(a.name, b.name) })
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%d CRD(s) could not be exported:\n\n", len(skipped))
+	for _, crd := range skipped {
+		fmt.Fprintf(&buf, "%s: %s\n", crd.name, crd.reason)
+	}
+	return buf.Bytes()
+}
+
+// fetchOptions returns the options for fetching a URL given by a client, applying the
+// configured allowed and denied address prefixes.
+func (s *Server) fetchOptions(allowPrivate bool) giturl.FetchOptions {
+	return giturl.FetchOptions{AllowPrivate: allowPrivate, Allow: s.opts.FetchAllow, Deny: s.opts.FetchDeny}
 }
 
 // GenerateHandler handles the generation of documentation from uploaded content.
@@ -564,6 +1220,16 @@ func (s *Server) GenerateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The documentation is shown inline by default, ?download=true saves it as a file instead.
+	var download bool
+	if value := r.URL.Query().Get("download"); value != "" {
+		var err error
+		if download, err = strconv.ParseBool(value); err != nil {
+			http.Error(w, "Bad Request: invalid download parameter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
 	crdContent := []byte(req.Content)
 
 	// If content is empty but URL is provided, fetch it
@@ -571,28 +1237,12 @@ func (s *Server) GenerateHandler(w http.ResponseWriter, r *http.Request) {
 		rawURL := giturl.ConvertGitURLToRaw(req.URL)
 		s.log.Info("fetching CRD from URL", "original", req.URL, "raw", rawURL)
 
-		resp, err := http.Get(rawURL) //nolint:gosec // user supplied url is intended
+		content, err := giturl.Fetch(r.Context(), rawURL, s.fetchOptions(s.opts.AllowPrivateFetch))
 		if err != nil {
 			s.log.Error("failed to fetch CRD from URL", "url", rawURL, "err", err)
 			http.Error(w, "Failed to fetch CRD: "+err.Error(), http.StatusBadRequest)
 			return
 		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			s.log.Error("failed to fetch CRD from URL", "url", rawURL, "status", resp.Status)
-			http.Error(w, "Failed to fetch CRD: "+resp.Status, http.StatusBadRequest)
-			return
-		}
-
-		// Read limited amount to prevent abuse
-		const maxFileSize = 10 * 1024 * 1024 // 10MB
-		content, err := io.ReadAll(io.LimitReader(resp.Body, maxFileSize))
-		if err != nil {
-			s.log.Error("failed to read CRD content", "err", err)
-			http.Error(w, "Failed to read CRD content", http.StatusInternalServerError)
-			return
-		}
 		crdContent = content
 	}
 
@@ -601,36 +1251,62 @@ func (s *Server) GenerateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Try to unmarshal as CRD
-	var crd apiextensionsv1.CustomResourceDefinition
-	// Use yaml.Unmarshal as it handles both YAML and JSON
-	if err := yaml.Unmarshal(crdContent, &crd); err != nil {
+	// The content may hold several YAML documents, each CRD among them is documented
+	crds, skipped, err := generator.ParseCRDs(crdContent)
+	for _, reason := range skipped {
+		s.log.Warn("skipping document", "reason", reason)
+	}
+	if err != nil {
 		http.Error(w, "Invalid CRD content: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	gen := generator.NewGenerator()
-	apiCRD := models.ToAPICRD(crd, 0)
+	gen := generator.NewGenerator(generator.Options{})
+	apiCRDs := make([]models.APICRD, 0, len(crds))
+	for _, crd := range crds {
+		apiCRDs = append(apiCRDs, models.ToAPICRD(crd, 0))
+	}
 
 	format := req.Format
 	if format == "" {
 		format = "html"
 	}
 
-	content, err := gen.Generate(apiCRD, format)
+	content, err := gen.GenerateAll(apiCRDs, format)
 	if err != nil {
 		s.log.Error("failed to generate documentation", "err", err)
 		http.Error(w, "Failed to generate documentation: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/plain") // Return content directly in body
+	w.Header().Set("Content-Type", contentType(format))
+	if download {
+		name := "crds"
+		if len(apiCRDs) == 1 {
+			name = apiCRDs[0].Metadata.Name
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.%s\"", name, getExtension(format)))
+	}
 	_, _ = w.Write(content)
 }
 
+// contentType returns the content type of documentation generated in format.
+func contentType(format string) string {
+	switch format {
+	case "markdown", "md":
+		return "text/markdown"
+	case "json":
+		return "application/json"
+	}
+	return "text/html"
+}
+
 func getExtension(format string) string {
-	if format == "markdown" || format == "md" {
+	switch format {
+	case "markdown", "md":
 		return "md"
+	case "json":
+		return "json"
 	}
 	return "html"
 }
@@ -0,0 +1,362 @@
+/*
+Copyright © 2025 Furkan Pehlivan furkanpehlivan34@gmail.com
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package web
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/pehlicd/crd-wizard/internal/giturl"
+	"github.com/pehlicd/crd-wizard/internal/k8s"
+)
+
+const (
+	// webhookTimeout bounds a single notification request.
+	webhookTimeout = 10 * time.Second
+	// webhookQueueSize is the number of notifications buffered per webhook before new ones are dropped.
+	webhookQueueSize = 100
+	// maxWebhooks bounds the number of webhooks registered at once.
+	maxWebhooks = 100
+	// maxWebhookRequestSize bounds the request body accepted by WebhooksHandler.
+	maxWebhookRequestSize = 64 << 10
+)
+
+var (
+	// errInvalidWebhookURL is returned by AddWebhook for URLs that cannot receive notifications.
+	errInvalidWebhookURL = errors.New("invalid webhook URL")
+	// errDuplicateWebhook is returned by AddWebhook when the URL is already notified about the CRD.
+	errDuplicateWebhook = errors.New("webhook already registered")
+	// errTooManyWebhooks is returned by AddWebhook once maxWebhooks are registered.
+	errTooManyWebhooks = fmt.Errorf("at most %d webhooks can be registered", maxWebhooks)
+)
+
+// Webhook is a URL notified whenever an instance of a CRD changes.
+type Webhook struct {
+	ID        string    `json:"id"`
+	Cluster   string    `json:"cluster"`
+	CRDName   string    `json:"crdName"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	cancel context.CancelFunc
+	events chan webhookEvent
+}
+
+// webhookEvent is the JSON body posted to a webhook.
+type webhookEvent struct {
+	Type            k8s.CREventType `json:"type"`
+	Cluster         string          `json:"cluster"`
+	CRDName         string          `json:"crdName"`
+	Namespace       string          `json:"namespace,omitempty"`
+	Name            string          `json:"name"`
+	UID             string          `json:"uid"`
+	ResourceVersion string          `json:"resourceVersion"`
+	Timestamp       string          `json:"timestamp"`
+}
+
+// crdWatch is the informer watching the instances of one CRD, shared by all webhooks of the CRD.
+type crdWatch struct {
+	cancel context.CancelFunc
+	hooks  map[string]*Webhook
+}
+
+// webhookRegistry holds the webhooks registered with the server and the watches feeding them.
+type webhookRegistry struct {
+	mu      sync.Mutex
+	hooks   map[string]*Webhook
+	watches map[string]*crdWatch
+}
+
+func newWebhookRegistry() *webhookRegistry {
+	return &webhookRegistry{hooks: make(map[string]*Webhook), watches: make(map[string]*crdWatch)}
+}
+
+// watchKey identifies the watch of a CRD in a cluster.
+func watchKey(cluster, crdName string) string {
+	return cluster + "/" + crdName
+}
+
+// AddWebhook posts a JSON event to rawURL for every instance of a CRD in the client's cluster
+// that is added, modified or deleted, until the webhook is removed or the server stops. All
+// webhooks of a CRD share a single watch. allowPrivate permits URLs that resolve to private
+// addresses, which should only be set for URLs configured by the operator.
+func (s *Server) AddWebhook(client *k8s.Client, crdName, rawURL string, allowPrivate bool) (*Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w %q: an absolute http or https URL is required", errInvalidWebhookURL, rawURL)
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate webhook id: %w", err)
+	}
+
+	hook := &Webhook{
+		ID:        hex.EncodeToString(id),
+		Cluster:   client.ClusterName,
+		CRDName:   crdName,
+		URL:       u.String(),
+		CreatedAt: time.Now(),
+		events:    make(chan webhookEvent, webhookQueueSize),
+	}
+
+	s.webhooks.mu.Lock()
+	defer s.webhooks.mu.Unlock()
+
+	if len(s.webhooks.hooks) >= maxWebhooks {
+		return nil, errTooManyWebhooks
+	}
+	key := watchKey(hook.Cluster, crdName)
+	watch := s.webhooks.watches[key]
+	if watch != nil {
+		for _, other := range watch.hooks {
+			if other.URL == hook.URL {
+				return nil, fmt.Errorf("%w: %s is already notified about %s as %s", errDuplicateWebhook, hook.URL, crdName, other.ID)
+			}
+		}
+	} else {
+		if watch, err = s.watchCRD(client, crdName); err != nil {
+			return nil, err
+		}
+		s.webhooks.watches[key] = watch
+	}
+
+	ctx, cancel := s.stoppableContext()
+	hook.cancel = cancel
+	watch.hooks[hook.ID] = hook
+	s.webhooks.hooks[hook.ID] = hook
+
+	// Notifications are sent one at a time so the receiver sees them in order.
+	fetchOpts := s.fetchOptions(allowPrivate)
+	fetchOpts.Timeout = webhookTimeout
+	httpClient := giturl.NewHTTPClient(fetchOpts)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-hook.events:
+				if err := postWebhookEvent(ctx, httpClient, hook.URL, event); err != nil {
+					s.log.Warn("failed to notify webhook", "webhook", hook.ID, "url", hook.URL, "err", err)
+				}
+			}
+		}
+	}()
+
+	s.log.Info("registered webhook", "webhook", hook.ID, "crd", crdName, "cluster", hook.Cluster, "url", hook.URL)
+	return hook, nil
+}
+
+// watchCRD starts watching the instances of a CRD and fans every change out to the webhooks
+// of the returned watch. The caller holds the registry lock.
+func (s *Server) watchCRD(client *k8s.Client, crdName string) (*crdWatch, error) {
+	ctx, cancel := s.stoppableContext()
+	watch := &crdWatch{cancel: cancel, hooks: make(map[string]*Webhook)}
+	err := client.WatchCRs(ctx, crdName, func(e k8s.CREvent) {
+		event := webhookEvent{
+			Type:            e.Type,
+			Cluster:         client.ClusterName,
+			CRDName:         crdName,
+			Namespace:       e.Object.GetNamespace(),
+			Name:            e.Object.GetName(),
+			UID:             string(e.Object.GetUID()),
+			ResourceVersion: e.Object.GetResourceVersion(),
+			Timestamp:       k8s.Timestamp(time.Now()),
+		}
+
+		s.webhooks.mu.Lock()
+		defer s.webhooks.mu.Unlock()
+		for _, hook := range watch.hooks {
+			select {
+			case hook.events <- event:
+			default:
+				s.log.Warn("webhook queue is full, dropping event", "webhook", hook.ID, "crd", crdName, "name", event.Name)
+			}
+		}
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return watch, nil
+}
+
+// stoppableContext returns a context that is canceled when the server stops.
+func (s *Server) stoppableContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-s.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// RemoveWebhook stops the webhook with the given id and reports whether it existed. The watch
+// of its CRD stops with the last webhook of the CRD.
+func (s *Server) RemoveWebhook(id string) bool {
+	s.webhooks.mu.Lock()
+	defer s.webhooks.mu.Unlock()
+	hook, ok := s.webhooks.hooks[id]
+	if !ok {
+		return false
+	}
+	hook.cancel()
+	delete(s.webhooks.hooks, id)
+
+	key := watchKey(hook.Cluster, hook.CRDName)
+	if watch := s.webhooks.watches[key]; watch != nil {
+		delete(watch.hooks, id)
+		if len(watch.hooks) == 0 {
+			watch.cancel()
+			delete(s.webhooks.watches, key)
+		}
+	}
+	return true
+}
+
+// Webhooks returns the registered webhooks, oldest first.
+func (s *Server) Webhooks() []*Webhook {
+	s.webhooks.mu.Lock()
+	defer s.webhooks.mu.Unlock()
+	hooks := make([]*Webhook, 0, len(s.webhooks.hooks))
+	for _, hook := range s.webhooks.hooks {
+		hooks = append(hooks, hook)
+	}
+	slices.SortFunc(hooks, func(a, b *Webhook) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return hooks
+}
+
+func postWebhookEvent(ctx context.Context, client *http.Client, url string, event webhookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "crd-wizard")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return nil
+}
+
+// WebhooksHandler lists (GET), registers (POST) and removes (DELETE ?id=) webhooks notified
+// about changes to the instances of a CRD. Webhooks registered through the API may only
+// target private addresses when the server allows private fetches. All methods require the
+// webhook token when the server has one, since webhook URLs often embed secrets.
+func (s *Server) WebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.webhookAuthorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.respondWithJSON(w, r, http.StatusOK, s.Webhooks())
+
+	case http.MethodPost:
+		client, err := s.getClientForRequest(r)
+		if err != nil {
+			s.log.Error("cluster not found", "err", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var req struct {
+			CRDName string `json:"crdName"`
+			URL     string `json:"url"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookRequestSize)).Decode(&req); err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		if req.CRDName == "" || req.URL == "" {
+			http.Error(w, "crdName and url are required", http.StatusBadRequest)
+			return
+		}
+
+		hook, err := s.AddWebhook(client, req.CRDName, req.URL, s.opts.AllowPrivateFetch)
+		if err != nil {
+			switch {
+			case errors.Is(err, errInvalidWebhookURL):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, errDuplicateWebhook):
+				http.Error(w, err.Error(), http.StatusConflict)
+			case errors.Is(err, errTooManyWebhooks):
+				http.Error(w, err.Error(), http.StatusTooManyRequests)
+			case apierrors.IsNotFound(err):
+				http.Error(w, fmt.Sprintf("CRD %s not found", req.CRDName), http.StatusNotFound)
+			default:
+				s.log.Error("failed to register webhook", "crd", req.CRDName, "err", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			}
+			return
+		}
+		s.respondWithJSON(w, r, http.StatusCreated, hook)
+
+	case http.MethodDelete:
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			http.Error(w, "id query parameter is required", http.StatusBadRequest)
+			return
+		}
+		if !s.RemoveWebhook(id) {
+			http.Error(w, "webhook not found", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Only GET, POST and DELETE methods are allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// webhookAuthorized reports whether r carries the webhook token, or the server has none.
+func (s *Server) webhookAuthorized(r *http.Request) bool {
+	if s.opts.WebhookToken == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.WebhookToken)) == 1
+}